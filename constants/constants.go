@@ -7,7 +7,9 @@ import (
 
 const (
   LogPrefixFmt = "%-17s "
-  TwelveHours = 12 * time.Hour
+  TimestampFmt = "2006-01-02 15:04"
+  TwelveHours  = 12 * time.Hour
+  OneDay       = 24 * time.Hour
 )
 
 var (
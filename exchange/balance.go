@@ -0,0 +1,29 @@
+package exchange
+
+import "github.com/shopspring/decimal"
+
+//
+// Balance generically provides an interface to objects that represent how much of a particular
+// asset an account holds on an exchange, as provided in a response from a call to an exchange's API
+// endpoint.
+//
+type Balance interface {
+
+  //
+  // Asset returns the symbol of the asset that the balance is of (e.g. "BTC" or "USD").
+  //
+  Asset() string
+
+  //
+  // Free returns a pointer to the structure representing the amount of the asset that is available
+  // to be traded.
+  //
+  Free() *decimal.Decimal
+
+  //
+  // Locked returns a pointer to the structure representing the amount of the asset that is tied up
+  // in open orders (or otherwise unavailable to be traded).
+  //
+  Locked() *decimal.Decimal
+
+}
@@ -0,0 +1,51 @@
+package binance
+
+import (
+  "encoding/json"
+  "github.com/shopspring/decimal"
+)
+
+//
+// Balance implements the exchange.Balance interface for asset balances provided by the Binance.US
+// API.
+//
+type Balance struct {
+  asset  string
+  free   decimal.Decimal
+  locked decimal.Decimal
+}
+
+//
+// UnmarshalJSON implements the json.Unmarshaller interface for Balance structures so that the JSON
+// objects provided by the Binance.US API that represent them can be properly unmarshalled.
+//
+func (o *Balance) UnmarshalJSON(data []byte) error {
+  var raw struct {
+    Asset  string          `json:"asset"`
+    Free   decimal.Decimal `json:"free"`
+    Locked decimal.Decimal `json:"locked"`
+  }
+
+  err := json.Unmarshal(data, &raw)
+  if err != nil {
+    return err
+  }
+
+  o.asset = raw.Asset
+  o.free = raw.Free
+  o.locked = raw.Locked
+
+  return nil
+}
+
+func (o *Balance) Asset() string {
+  return o.asset
+}
+
+func (o *Balance) Free() *decimal.Decimal {
+  return &o.free
+}
+
+func (o *Balance) Locked() *decimal.Decimal {
+  return &o.locked
+}
@@ -1,12 +1,21 @@
 package binance
 
 import (
+  "crypto/hmac"
+  "crypto/sha256"
+  "encoding/hex"
   "encoding/json"
+  "errors"
   "fmt"
   "github.com/lukehollenback/goose/exchange"
+  "github.com/shopspring/decimal"
   "io"
   "io/ioutil"
   "net/http"
+  "net/url"
+  "strconv"
+  "strings"
+  "sync"
   "time"
 )
 
@@ -14,14 +23,20 @@ import (
 // Client implements the exchange.Client interface for the Binance.US API.
 //
 type Client struct {
-  apiKey    string
-  apiSecret string
+  mu         *sync.Mutex
+  apiKey     string
+  apiSecret  string
   httpClient *http.Client
+  recvWindow time.Duration // How long after its timestamp a signed request remains valid to the server.
+  timeOffset time.Duration // How far ahead of the local clock the Binance.US server's clock is.
+  timeSynced bool          // Whether or not the time offset has been calculated yet.
 }
 
 func NewClient() *Client {
   return &Client{
+    mu:         &sync.Mutex{},
     httpClient: &http.Client{},
+    recvWindow: DefaultRecvWindow,
   }
 }
 
@@ -32,6 +47,73 @@ func (o *Client) Auth(key string, secret string) (exchange.Response, error) {
   return nil, nil
 }
 
+//
+// SetRecvWindow tells the client how long after their timestamps signed requests should remain valid
+// to the Binance.US server. Binance.US does not allow windows larger than one minute.
+//
+func (o *Client) SetRecvWindow(recvWindow time.Duration) error {
+  if recvWindow <= 0 || recvWindow > MaxRecvWindow {
+    return fmt.Errorf("receive window must be greater than zero and no more than %s", MaxRecvWindow)
+  }
+
+  o.mu.Lock()
+  defer o.mu.Unlock()
+
+  o.recvWindow = recvWindow
+
+  return nil
+}
+
+//
+// SyncTime retrieves the current time from the Binance.US server and calculates how far its clock
+// is offset from the local clock so that signed requests are not rejected for having timestamps
+// outside of the receive window. It is automatically called before the first signed request is
+// made and whenever the server rejects a signed request's timestamp.
+//
+func (o *Client) SyncTime() (exchange.Response, error) {
+  //
+  // Make the endpoint request and handle any errors along the way. We track when the request was
+  // sent and when the response came back so that we can account for network latency.
+  //
+  sent := time.Now()
+
+  resp, err := o.request("GET", TimeURL, nil)
+  if err != nil {
+    return resp, err
+  }
+
+  received := time.Now()
+
+  //
+  // Parse the response.
+  //
+  var serverTime struct {
+    ServerTime int64 `json:"serverTime"`
+  }
+
+  err = json.Unmarshal(resp.body, &serverTime)
+  if err != nil {
+    return resp, err
+  }
+
+  //
+  // Calculate the offset of the server's clock against the local clock as of the midpoint of the
+  // round trip.
+  //
+  // NOTE ~> We are assuming that the request and response legs of the round trip took roughly the
+  //  same amount of time.
+  //
+  midpoint := sent.Add(received.Sub(sent) / 2)
+
+  o.mu.Lock()
+  defer o.mu.Unlock()
+
+  o.timeOffset = time.Unix(0, serverTime.ServerTime*MillisInNano).Sub(midpoint)
+  o.timeSynced = true
+
+  return resp, nil
+}
+
 func (o Client) RetrieveCandles(
     symbol string,
     interval exchange.Interval,
@@ -82,6 +164,233 @@ func (o *Client) RetrieveSymbol(source string, dest string) string {
   return fmt.Sprintf("%s%s", source, dest)
 }
 
+func (o *Client) PlaceMarketOrder(
+    symbol string,
+    side exchange.OrderSide,
+    quantity decimal.Decimal,
+) (exchange.Response, error) {
+  //
+  // Build the request parameters.
+  //
+  params := url.Values{}
+  params.Set("symbol", symbol)
+  params.Set("side", side.String())
+  params.Set("type", exchange.Market.String())
+  params.Set("quantity", quantity.String())
+  params.Set("newOrderRespType", "RESULT")
+
+  //
+  // Make the endpoint request and parse the resulting order.
+  //
+  return o.orderRequest("POST", params)
+}
+
+func (o *Client) PlaceLimitOrder(
+    symbol string,
+    side exchange.OrderSide,
+    quantity decimal.Decimal,
+    price decimal.Decimal,
+    timeInForce exchange.TimeInForce,
+) (exchange.Response, error) {
+  //
+  // Build the request parameters.
+  //
+  params := url.Values{}
+  params.Set("symbol", symbol)
+  params.Set("side", side.String())
+  params.Set("type", exchange.Limit.String())
+  params.Set("timeInForce", timeInForce.String())
+  params.Set("quantity", quantity.String())
+  params.Set("price", price.String())
+  params.Set("newOrderRespType", "RESULT")
+
+  //
+  // Make the endpoint request and parse the resulting order.
+  //
+  return o.orderRequest("POST", params)
+}
+
+func (o *Client) CancelOrder(symbol string, orderID string) (exchange.Response, error) {
+  //
+  // Build the request parameters.
+  //
+  params := url.Values{}
+  params.Set("symbol", symbol)
+  params.Set("orderId", orderID)
+
+  //
+  // Make the endpoint request and parse the resulting order.
+  //
+  return o.orderRequest("DELETE", params)
+}
+
+func (o *Client) RetrieveOrder(symbol string, orderID string) (exchange.Response, error) {
+  //
+  // Build the request parameters.
+  //
+  params := url.Values{}
+  params.Set("symbol", symbol)
+  params.Set("orderId", orderID)
+
+  //
+  // Make the endpoint request and parse the resulting order.
+  //
+  return o.orderRequest("GET", params)
+}
+
+func (o *Client) RetrieveBalances() (exchange.Response, error) {
+  //
+  // Make the endpoint request and handle any errors along the way.
+  //
+  resp, err := o.signedRequest("GET", AccountURL, url.Values{})
+  if err != nil {
+    return resp, err
+  }
+
+  //
+  // Parse the response.
+  //
+  var account struct {
+    Balances []*Balance `json:"balances"`
+  }
+
+  err = json.Unmarshal(resp.body, &account)
+  if err != nil {
+    return resp, err
+  }
+
+  //
+  // Finish packing the wrapped response and return it.
+  //
+  resp.balances = account.Balances
+
+  return resp, nil
+}
+
+//
+// orderRequest makes the specified request against the order endpoint of the Binance.US API and
+// parses the order that is provided in the response.
+//
+func (o *Client) orderRequest(method string, params url.Values) (exchange.Response, error) {
+  //
+  // Make the endpoint request and handle any errors along the way.
+  //
+  resp, err := o.signedRequest(method, OrderURL, params)
+  if err != nil {
+    return resp, err
+  }
+
+  //
+  // Parse the response.
+  //
+  order := &Order{}
+
+  err = json.Unmarshal(resp.body, order)
+  if err != nil {
+    return resp, err
+  }
+
+  //
+  // Finish packing the wrapped response and return it.
+  //
+  resp.order = order
+
+  return resp, nil
+}
+
+//
+// signedRequest makes the specified request to one of the Binance.US API's SIGNED endpoints. The
+// timestamp and receive window parameters are added to the provided parameters, and the whole
+// payload is then signed with the client's API secret. If the server rejects the timestamp, the
+// client's clock offset is re-synchronized and the request is retried once.
+//
+func (o *Client) signedRequest(method string, endpoint string, params url.Values) (*Response, error) {
+  //
+  // Make sure that we actually have a secret to sign with.
+  //
+  if o.apiSecret == "" {
+    return nil, errors.New("cannot make a signed request without an API secret")
+  }
+
+  //
+  // Make sure that we know how far off our clock is from the server's before we generate any
+  // timestamps.
+  //
+  o.mu.Lock()
+  synced := o.timeSynced
+  o.mu.Unlock()
+
+  if !synced {
+    if _, err := o.SyncTime(); err != nil {
+      return nil, err
+    }
+  }
+
+  //
+  // Make the request. If it is rejected because of its timestamp, our clock has likely drifted, so
+  // re-synchronize and try one more time.
+  //
+  resp, err := o.doSignedRequest(method, endpoint, params)
+
+  if apiErr, ok := err.(*APIError); ok && apiErr.Code == InvalidTimestampCode {
+    if _, err := o.SyncTime(); err != nil {
+      return nil, err
+    }
+
+    resp, err = o.doSignedRequest(method, endpoint, params)
+  }
+
+  return resp, err
+}
+
+//
+// doSignedRequest timestamps, signs, and makes the specified request.
+//
+// NOTE ~> Parameters of POST requests are provided in the request body, while parameters of all
+//  other requests are provided in the query string.
+//
+func (o *Client) doSignedRequest(method string, endpoint string, params url.Values) (*Response, error) {
+  //
+  // Build the payload to sign.
+  //
+  o.mu.Lock()
+  now := time.Now().Add(o.timeOffset)
+  recvWindow := o.recvWindow
+  o.mu.Unlock()
+
+  signed := url.Values{}
+
+  for k, v := range params {
+    signed[k] = v
+  }
+
+  signed.Set("timestamp", strconv.FormatInt(now.UnixNano()/MillisInNano, 10))
+  signed.Set("recvWindow", strconv.FormatInt(int64(recvWindow/time.Millisecond), 10))
+
+  payload := signed.Encode()
+  payload = payload + "&signature=" + sign(o.apiSecret, payload)
+
+  //
+  // Make the endpoint request.
+  //
+  if method == "POST" {
+    return o.request(method, endpoint, strings.NewReader(payload))
+  }
+
+  return o.request(method, endpoint+"?"+payload, nil)
+}
+
+//
+// sign generates the hex-encoded HMAC SHA256 signature of the provided payload using the provided
+// secret as the key.
+//
+func sign(secret string, payload string) string {
+  mac := hmac.New(sha256.New, []byte(secret))
+  mac.Write([]byte(payload))
+
+  return hex.EncodeToString(mac.Sum(nil))
+}
+
 //
 // request makes the specified request to the Binance.US API and returns a wrapped response (parsed
 // as much as generically possible) and/or an error if something went wrong.
@@ -90,24 +399,23 @@ func (o *Client) request(method string, url string, body io.Reader) (*Response,
   //
   // Make a request to the endpoint.
   //
-  req, err := http.NewRequest("GET", url, nil)
+  req, err := http.NewRequest(method, url, body)
   if err != nil {
     return nil, err
   }
 
   req.Header.Add(APIKeyHeader, o.apiKey)
 
+  if body != nil {
+    req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+  }
+
   resp, err := o.httpClient.Do(req)
   if err != nil {
     return nil, err
   }
 
-  //
-  // Make sure the error code was valid.
-  //
-  if resp.StatusCode != 200 {
-    return nil,exchange.NewHTTPError(resp.StatusCode)
-  }
+  defer resp.Body.Close()
 
   //
   // Begin wrapping the response in the standard response structure.
@@ -129,14 +437,25 @@ func (o *Client) request(method string, url string, body io.Reader) (*Response,
   //
   // Check the response for API errors.
   //
-  var apiErr *APIError
+  // NOTE ~> Binance.US responds to rejected requests (e.g. orders with insufficient funds) with a
+  //  4XX status code and an API error payload. The API error is far more useful than the status
+  //  code, so we check for it first.
+  //
+  apiErr := &APIError{}
 
-  _ = json.Unmarshal(respBody, &apiErr)
+  _ = json.Unmarshal(respBody, apiErr)
 
   if apiErr.populated() {
     return wrappedResp, apiErr
   }
 
+  //
+  // Make sure the status code was valid.
+  //
+  if resp.StatusCode != 200 {
+    return wrappedResp, exchange.NewHTTPError(resp.StatusCode)
+  }
+
   //
   // Return the wrapped response.
   //
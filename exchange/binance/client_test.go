@@ -0,0 +1,18 @@
+package binance
+
+import "testing"
+
+func TestSign(t *testing.T) {
+  //
+  // Sign the example payload from the Binance.US API documentation and make sure that we generate
+  // the same signature that the documentation does.
+  //
+  secret := "NhqPtmdSJYdKjVHjA7PZj4Mge3R5YNiP1e3UZjInClVN65XAbvqqM6A7H5fATj0j"
+  payload := "symbol=LTCBTC&side=BUY&type=LIMIT&timeInForce=GTC&quantity=1&price=0.1&recvWindow=5000" +
+      "&timestamp=1499827319559"
+  expected := "c8db56825ae71d6d79447849e617115f4a920fa2acdcab2b053c4b2838bd6b71"
+
+  if signature := sign(secret, payload); signature != expected {
+    t.Errorf("Expected signature to be %s but was instead %s.", expected, signature)
+  }
+}
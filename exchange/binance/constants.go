@@ -1,10 +1,25 @@
 package binance
 
+import "time"
+
 const (
   APIKeyHeader = "X-MBX-APIKEY"
 
+  DefaultRecvWindow = 5 * time.Second
+  MaxRecvWindow     = 60 * time.Second
+
+  InvalidTimestampCode = -1021
+
   BaseURL    = "https://api.binance.us"
+  AccountURL = BaseURL + "/api/v3/account"
   CandlesURL = BaseURL + "/api/v3/klines"
+  OrderURL   = BaseURL + "/api/v3/order"
+  TimeURL    = BaseURL + "/api/v3/time"
+
+  FeedURL = "wss://stream.binance.us:9443/ws"
+
+  EventBufferSize = 256
+  PingInterval    = 10 * time.Second
 
   MillisInNano = 1000000
 )
@@ -0,0 +1,182 @@
+package binance
+
+import (
+  "encoding/json"
+  "fmt"
+  "github.com/lukehollenback/goose/exchange"
+  "github.com/shopspring/decimal"
+  "strconv"
+  "time"
+)
+
+var (
+  orderSides = map[string]exchange.OrderSide{
+    "BUY":  exchange.Buy,
+    "SELL": exchange.Sell,
+  }
+
+  orderTypes = map[string]exchange.OrderType{
+    "MARKET": exchange.Market,
+    "LIMIT":  exchange.Limit,
+  }
+
+  orderStatuses = map[string]exchange.OrderStatus{
+    "NEW":              exchange.New,
+    "PARTIALLY_FILLED": exchange.PartiallyFilled,
+    "FILLED":           exchange.Filled,
+    "CANCELED":         exchange.Canceled,
+    "PENDING_CANCEL":   exchange.PendingCancel,
+    "REJECTED":         exchange.Rejected,
+    "EXPIRED":          exchange.Expired,
+  }
+
+  timesInForce = map[string]exchange.TimeInForce{
+    "GTC": exchange.GoodTillCanceled,
+    "IOC": exchange.ImmediateOrCancel,
+    "FOK": exchange.FillOrKill,
+  }
+)
+
+//
+// Order implements the exchange.Order interface for orders provided by the Binance.US API.
+//
+type Order struct {
+  id          string
+  symbol      string
+  side        exchange.OrderSide
+  orderType   exchange.OrderType
+  status      exchange.OrderStatus
+  timeInForce exchange.TimeInForce
+  time        time.Time
+  price       decimal.Decimal
+  qty         decimal.Decimal
+  filledQty   decimal.Decimal
+  filledQuote decimal.Decimal
+}
+
+//
+// UnmarshalJSON implements the json.Unmarshaller interface for Order structures so that the JSON
+// objects provided by the Binance.US API that represent them can be properly unmarshalled.
+//
+func (o *Order) UnmarshalJSON(data []byte) error {
+  //
+  // Unmarshall the provided JSON string into a raw structure.
+  //
+  // NOTE ~> The order placement endpoint provides a "transactTime" field, while the order status
+  //  endpoint instead provides "time" and "updateTime" fields. We accept all of them and use
+  //  whichever is the most recent.
+  //
+  var raw struct {
+    Symbol              string          `json:"symbol"`
+    OrderID             int64           `json:"orderId"`
+    TransactTime        int64           `json:"transactTime"`
+    Time                int64           `json:"time"`
+    UpdateTime          int64           `json:"updateTime"`
+    Price               decimal.Decimal `json:"price"`
+    OrigQty             decimal.Decimal `json:"origQty"`
+    ExecutedQty         decimal.Decimal `json:"executedQty"`
+    CummulativeQuoteQty decimal.Decimal `json:"cummulativeQuoteQty"`
+    Status              string          `json:"status"`
+    TimeInForce         string          `json:"timeInForce"`
+    Type                string          `json:"type"`
+    Side                string          `json:"side"`
+  }
+
+  err := json.Unmarshal(data, &raw)
+  if err != nil {
+    return err
+  }
+
+  //
+  // Parse the enumerated values of the order.
+  //
+  var ok bool
+
+  if o.side, ok = orderSides[raw.Side]; !ok {
+    return fmt.Errorf("failed to parse order side (%s)", raw.Side)
+  }
+
+  if o.orderType, ok = orderTypes[raw.Type]; !ok {
+    return fmt.Errorf("failed to parse order type (%s)", raw.Type)
+  }
+
+  if o.status, ok = orderStatuses[raw.Status]; !ok {
+    return fmt.Errorf("failed to parse order status (%s)", raw.Status)
+  }
+
+  if raw.TimeInForce != "" {
+    if o.timeInForce, ok = timesInForce[raw.TimeInForce]; !ok {
+      return fmt.Errorf("failed to parse order time in force (%s)", raw.TimeInForce)
+    }
+  }
+
+  //
+  // Determine the most recent timestamp that was provided.
+  //
+  millis := raw.TransactTime
+
+  if raw.Time > millis {
+    millis = raw.Time
+  }
+
+  if raw.UpdateTime > millis {
+    millis = raw.UpdateTime
+  }
+
+  //
+  // Copy over the remaining values of the order.
+  //
+  o.id = strconv.FormatInt(raw.OrderID, 10)
+  o.symbol = raw.Symbol
+  o.time = time.Unix(0, millis*MillisInNano)
+  o.price = raw.Price
+  o.qty = raw.OrigQty
+  o.filledQty = raw.ExecutedQty
+  o.filledQuote = raw.CummulativeQuoteQty
+
+  return nil
+}
+
+func (o *Order) ID() string {
+  return o.id
+}
+
+func (o *Order) Symbol() string {
+  return o.symbol
+}
+
+func (o *Order) Side() exchange.OrderSide {
+  return o.side
+}
+
+func (o *Order) Type() exchange.OrderType {
+  return o.orderType
+}
+
+func (o *Order) Status() exchange.OrderStatus {
+  return o.status
+}
+
+func (o *Order) TimeInForce() exchange.TimeInForce {
+  return o.timeInForce
+}
+
+func (o *Order) Time() *time.Time {
+  return &o.time
+}
+
+func (o *Order) Price() *decimal.Decimal {
+  return &o.price
+}
+
+func (o *Order) Quantity() *decimal.Decimal {
+  return &o.qty
+}
+
+func (o *Order) FilledQuantity() *decimal.Decimal {
+  return &o.filledQty
+}
+
+func (o *Order) FilledQuoteQuantity() *decimal.Decimal {
+  return &o.filledQuote
+}
@@ -13,6 +13,8 @@ type Response struct {
   response *http.Response
   body     []byte
   candles  []*Candle
+  order    *Order
+  balances []*Balance
 }
 
 func (o *Response) Raw() *http.Response {
@@ -32,3 +34,21 @@ func (o *Response) Candles() []exchange.Candle {
 
   return ret
 }
+
+func (o *Response) Order() exchange.Order {
+  if o.order == nil {
+    return nil
+  }
+
+  return o.order
+}
+
+func (o *Response) Balances() []exchange.Balance {
+  ret := make([]exchange.Balance, len(o.balances))
+
+  for i, v := range o.balances {
+    ret[i] = v
+  }
+
+  return ret
+}
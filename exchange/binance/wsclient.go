@@ -0,0 +1,330 @@
+package binance
+
+import (
+  "encoding/json"
+  "errors"
+  "fmt"
+  "github.com/lukehollenback/goose/exchange"
+  "github.com/shopspring/decimal"
+  "strings"
+  "sync"
+  "time"
+
+  ws "github.com/gorilla/websocket"
+)
+
+var (
+  streamSuffixes = map[exchange.Channel]string{
+    exchange.TradeChannel: "@trade",
+    exchange.KlineChannel: "@kline_" + exchange.OneMinute.String(),
+  }
+)
+
+//
+// WSClient implements the exchange.WSClient interface for the Binance.US websocket feed.
+//
+// NOTE ~> Binance.US does not provide a heartbeat stream, and its server only sends a ping frame
+//  every few minutes. Thus, we also regularly ping the server ourselves and report both its pings
+//  and its pongs as heartbeat events.
+//
+type WSClient struct {
+  mu       *sync.Mutex
+  conn     *ws.Conn
+  chEvents chan *exchange.Event
+  closing  bool
+  nextID   int
+}
+
+func NewWSClient() *WSClient {
+  return &WSClient{
+    mu: &sync.Mutex{},
+  }
+}
+
+func (o *WSClient) Connect() error {
+  o.mu.Lock()
+  defer o.mu.Unlock()
+
+  //
+  // Connect to the Binance.US websocket feed.
+  //
+  var wsDialer ws.Dialer
+
+  conn, _, err := wsDialer.Dial(FeedURL, nil)
+  if err != nil {
+    return err
+  }
+
+  //
+  // (Re)initialize our instance variables.
+  //
+  o.conn = conn
+  o.chEvents = make(chan *exchange.Event, EventBufferSize)
+  o.closing = false
+
+  //
+  // Answer pings from the server and report both them and the server's answers to our own pings as
+  // heartbeats.
+  //
+  // NOTE ~> Control frame handlers are called from within the read loop, so there is no risk of the
+  //  event channel having been closed out from under them.
+  //
+  chEvents := o.chEvents
+
+  heartbeat := func() {
+    select {
+    case chEvents <- &exchange.Event{Type: exchange.HeartbeatEvent, Time: time.Now()}:
+    default:
+    }
+  }
+
+  conn.SetPingHandler(func(data string) error {
+    heartbeat()
+
+    return conn.WriteControl(ws.PongMessage, []byte(data), time.Now().Add(time.Second))
+  })
+
+  conn.SetPongHandler(func(string) error {
+    heartbeat()
+
+    return nil
+  })
+
+  //
+  // Begin reading messages off of (and pinging over) the new connection.
+  //
+  go o.read(o.conn, o.chEvents)
+  go o.ping(o.conn)
+
+  return nil
+}
+
+func (o *WSClient) Subscribe(symbol string, channels ...exchange.Channel) error {
+  o.mu.Lock()
+  defer o.mu.Unlock()
+
+  if o.conn == nil {
+    return errors.New("cannot subscribe before connecting to the Binance.US websocket feed")
+  }
+
+  //
+  // Build out the subscription message.
+  //
+  // NOTE ~> Heartbeats do not need to be subscribed to, as they are derived from the pings that the
+  //  server always sends.
+  //
+  params := make([]string, 0, len(channels))
+
+  for _, channel := range channels {
+    if channel == exchange.HeartbeatChannel {
+      continue
+    }
+
+    suffix, ok := streamSuffixes[channel]
+    if !ok {
+      return fmt.Errorf("the Binance.US websocket feed does not support the %s channel", channel)
+    }
+
+    params = append(params, strings.ToLower(symbol)+suffix)
+  }
+
+  o.nextID++
+
+  subscribe := map[string]interface{}{
+    "method": "SUBSCRIBE",
+    "params": params,
+    "id":     o.nextID,
+  }
+
+  //
+  // Send the subscription message.
+  //
+  return o.conn.WriteJSON(subscribe)
+}
+
+func (o *WSClient) Events() <-chan *exchange.Event {
+  o.mu.Lock()
+  defer o.mu.Unlock()
+
+  return o.chEvents
+}
+
+func (o *WSClient) Close() error {
+  o.mu.Lock()
+  defer o.mu.Unlock()
+
+  if o.conn == nil {
+    return nil
+  }
+
+  o.closing = true
+
+  return o.conn.Close()
+}
+
+func (o *WSClient) RetrieveSymbol(source string, dest string) string {
+  return fmt.Sprintf("%s%s", source, dest)
+}
+
+//
+// read continuously reads messages from the provided connection and converts them into events
+// until the connection fails or is closed.
+//
+func (o *WSClient) read(conn *ws.Conn, chEvents chan<- *exchange.Event) {
+  defer close(chEvents)
+
+  for {
+    //
+    // Read the next message. If the read fails because we intentionally closed the connection, we
+    // simply stop. Otherwise, we report the failure.
+    //
+    _, data, err := conn.ReadMessage()
+    if err != nil {
+      o.mu.Lock()
+      closing := o.closing
+      o.mu.Unlock()
+
+      if !closing {
+        chEvents <- &exchange.Event{Type: exchange.ErrorEvent, Err: err}
+      }
+
+      return
+    }
+
+    //
+    // Convert the message into an event (if it is one that we care about).
+    //
+    if event, err := convert(data); err != nil {
+      chEvents <- &exchange.Event{Type: exchange.ErrorEvent, Err: err}
+    } else if event != nil {
+      chEvents <- event
+    }
+  }
+}
+
+//
+// ping regularly sends ping frames over the provided connection until the connection fails or is
+// closed.
+//
+func (o *WSClient) ping(conn *ws.Conn) {
+  ticker := time.NewTicker(PingInterval)
+  defer ticker.Stop()
+
+  for range ticker.C {
+    if err := conn.WriteControl(ws.PingMessage, nil, time.Now().Add(time.Second)); err != nil {
+      return
+    }
+  }
+}
+
+//
+// convert translates the provided raw Binance.US message into an exchange-agnostic event. If the
+// message is not one that we care about, nil is returned.
+//
+// NOTE ~> According to https://tinyurl.com/yxtl6tq4, subscription acknowledgements look like
+//  {"result": null, "id": 1}, while stream payloads carry their type in the "e" field.
+//
+func convert(data []byte) (*exchange.Event, error) {
+  //
+  // NOTE ~> Binance.US uses single-letter keys that differ only by case (e.g. "t" and "T"), while
+  //  Go's JSON decoder falls back to case-insensitive matching of keys that have no exact match.
+  //  Thus, every such key must be given a field – even if we do not care about its value – so that
+  //  it is not mistakenly decoded into its differently-cased sibling.
+  //
+  var raw struct {
+    ID        *int      `json:"id"`
+    Error     *APIError `json:"error"`
+    Type      string    `json:"e"`
+    EventTime int64     `json:"E"`
+    Symbol    string    `json:"s"`
+    TradeID   int64     `json:"t"`
+    Price     string    `json:"p"`
+    Qty       string    `json:"q"`
+    Time      int64     `json:"T"`
+    Maker     bool      `json:"m"`
+    Ignore    bool      `json:"M"`
+    Kline     *struct {
+      Start        int64           `json:"t"`
+      End          int64           `json:"T"`
+      FirstTradeID int64           `json:"f"`
+      LastTradeID  int64           `json:"L"`
+      Open         decimal.Decimal `json:"o"`
+      Close        decimal.Decimal `json:"c"`
+      High         decimal.Decimal `json:"h"`
+      Low          decimal.Decimal `json:"l"`
+      Volume       decimal.Decimal `json:"v"`
+      QuoteVolume  decimal.Decimal `json:"q"`
+      TakerVolume  decimal.Decimal `json:"V"`
+      TakerQuote   decimal.Decimal `json:"Q"`
+      Count        int             `json:"n"`
+      Closed       bool            `json:"x"`
+    } `json:"k"`
+  }
+
+  if err := json.Unmarshal(data, &raw); err != nil {
+    return nil, err
+  }
+
+  //
+  // Handle subscription acknowledgements and failures.
+  //
+  if raw.ID != nil {
+    if raw.Error != nil {
+      return nil, raw.Error
+    }
+
+    return &exchange.Event{Type: exchange.SubscribedEvent}, nil
+  }
+
+  //
+  // Handle stream payloads.
+  //
+  switch raw.Type {
+  case "trade":
+    price, err := decimal.NewFromString(raw.Price)
+    if err != nil {
+      return nil, fmt.Errorf("failed to parse price from message (Message: %s) (Error: %s)", data, err)
+    }
+
+    qty, err := decimal.NewFromString(raw.Qty)
+    if err != nil {
+      return nil, fmt.Errorf("failed to parse quantity from message (Message: %s) (Error: %s)", data, err)
+    }
+
+    return &exchange.Event{
+      Type:   exchange.TradeEvent,
+      Symbol: raw.Symbol,
+      Time:   time.Unix(0, raw.Time*MillisInNano),
+      Price:  price,
+      Size:   qty,
+    }, nil
+
+  case "kline":
+    // NOTE ~> Kline payloads are pushed every couple of seconds while the candle is still open. We
+    //  only care about them once they have closed out.
+
+    if raw.Kline == nil || !raw.Kline.Closed {
+      return nil, nil
+    }
+
+    k := raw.Kline
+
+    return &exchange.Event{
+      Type:   exchange.KlineEvent,
+      Symbol: raw.Symbol,
+      Time:   time.Unix(0, k.End*MillisInNano),
+      Candle: &Candle{
+        start:  time.Unix(0, k.Start*MillisInNano),
+        end:    time.Unix(0, k.End*MillisInNano),
+        open:   k.Open,
+        high:   k.High,
+        low:    k.Low,
+        close:  k.Close,
+        volume: k.Volume,
+        count:  k.Count,
+      },
+    }, nil
+  }
+
+  return nil, nil
+}
@@ -0,0 +1,62 @@
+package binance
+
+import (
+  "github.com/lukehollenback/goose/exchange"
+  "github.com/shopspring/decimal"
+  "testing"
+)
+
+func TestConvertTrade(t *testing.T) {
+  //
+  // Convert an example trade payload from the Binance.US API documentation.
+  //
+  data := []byte(`{"e":"trade","E":123456789,"s":"BNBBTC","t":12345,"p":"0.001","q":"100","b":88,"a":50,` +
+      `"T":123456785,"m":true,"M":true}`)
+
+  event, err := convert(data)
+  if err != nil {
+    t.Fatalf("Failed to convert trade payload. (Error: %s)", err)
+  }
+
+  if event.Type != exchange.TradeEvent {
+    t.Errorf("Expected a %s event but instead got a %s event.", exchange.TradeEvent, event.Type)
+  }
+
+  if !event.Price.Equal(decimal.RequireFromString("0.001")) {
+    t.Errorf("Expected price to be 0.001 but was instead %s.", event.Price)
+  }
+
+  if !event.Size.Equal(decimal.NewFromInt(100)) {
+    t.Errorf("Expected size to be 100 but was instead %s.", event.Size)
+  }
+
+  if millis := event.Time.UnixNano() / MillisInNano; millis != 123456785 {
+    t.Errorf("Expected trade time to be 123456785 but was instead %d.", millis)
+  }
+}
+
+func TestConvertKline(t *testing.T) {
+  //
+  // Convert an example closed kline payload from the Binance.US API documentation.
+  //
+  data := []byte(`{"e":"kline","E":123456789,"s":"BNBBTC","k":{"t":123400000,"T":123460000,"s":"BNBBTC",` +
+      `"i":"1m","f":100,"L":200,"o":"0.0010","c":"0.0020","h":"0.0025","l":"0.0015","v":"1000","n":100,` +
+      `"x":true,"q":"1.0000","V":"500","Q":"0.500","B":"123456"}}`)
+
+  event, err := convert(data)
+  if err != nil {
+    t.Fatalf("Failed to convert kline payload. (Error: %s)", err)
+  }
+
+  if event.Type != exchange.KlineEvent {
+    t.Fatalf("Expected a %s event but instead got a %s event.", exchange.KlineEvent, event.Type)
+  }
+
+  if low := event.Candle.Low(); !low.Equal(decimal.RequireFromString("0.0015")) {
+    t.Errorf("Expected low to be 0.0015 but was instead %s.", low)
+  }
+
+  if volume := event.Candle.Volume(); !volume.Equal(decimal.NewFromInt(1000)) {
+    t.Errorf("Expected volume to be 1000 but was instead %s.", volume)
+  }
+}
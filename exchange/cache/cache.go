@@ -0,0 +1,256 @@
+package cache
+
+import (
+  "fmt"
+  "github.com/lukehollenback/goose/constants"
+  "github.com/lukehollenback/goose/exchange"
+  "github.com/lukehollenback/goose/exchange/file"
+  "log"
+  "os"
+  "path/filepath"
+  "sync"
+  "time"
+)
+
+const (
+  Name     = "≪candle-cache≫"
+  DayFmt   = "2006-01-02"
+  PageSize = 1000
+)
+
+var (
+  logger *log.Logger
+)
+
+func init() {
+  //
+  // Initialize the logger.
+  //
+  logger = log.New(log.Writer(), fmt.Sprintf(constants.LogPrefixFmt, Name), log.Ldate|log.Ltime|log.Lmsgprefix)
+}
+
+//
+// Cache implements the exchange.CandleSource interface by reading historical candles from files on
+// disk. Candles that have not been cached yet are fetched from an underlying candle source (normally
+// an exchange's REST API client) and saved so that they never need to be fetched again.
+//
+// Candles are cached in one CSV file per UTC day, laid out as
+// "<directory>/<exchange>/<symbol>/<interval>/<YYYY-MM-DD>.csv". Days that have not completely
+// passed yet are never cached, as their candles are still subject to change.
+//
+type Cache struct {
+  mu       *sync.Mutex
+  dir      string
+  exchange string
+  source   exchange.CandleSource
+
+  lastPath    map[string]string         // The most recently-read day file for each symbol and interval.
+  lastCandles map[string][]*file.Candle // The candles held by the most recently-read day file for each symbol and interval.
+}
+
+//
+// New instantiates a new cache rooted at the specified directory for candles of the specified
+// exchange, which will be fetched from the provided candle source whenever they are missing.
+//
+func New(dir string, exchangeName string, source exchange.CandleSource) *Cache {
+  return &Cache{
+    mu:          &sync.Mutex{},
+    dir:         dir,
+    exchange:    exchangeName,
+    source:      source,
+    lastPath:    make(map[string]string),
+    lastCandles: make(map[string][]*file.Candle),
+  }
+}
+
+//
+// Fetch makes sure that every day of candles of the specified interval for the specified symbol
+// that overlaps the specified time range is cached, fetching any days that are missing. Returns the
+// number of days that had to be fetched.
+//
+func (o *Cache) Fetch(symbol string, interval exchange.Interval, start time.Time, end time.Time) (int, error) {
+  o.mu.Lock()
+  defer o.mu.Unlock()
+
+  fetched := 0
+
+  for day := start.UTC().Truncate(constants.OneDay); !day.After(end); day = day.Add(constants.OneDay) {
+    if !o.complete(day, interval) {
+      logger.Printf("Not caching %s %s candles for %s because the day is not over yet.", symbol, interval, day.Format(DayFmt))
+
+      continue
+    }
+
+    if _, err := os.Stat(o.path(symbol, interval, day)); err == nil {
+      continue
+    }
+
+    if _, err := o.fetchDay(symbol, interval, day); err != nil {
+      return fetched, err
+    }
+
+    fetched++
+  }
+
+  return fetched, nil
+}
+
+func (o *Cache) RetrieveCandles(
+    symbol string,
+    interval exchange.Interval,
+    start time.Time,
+    end time.Time,
+    limit int,
+) (exchange.Response, error) {
+  o.mu.Lock()
+  defer o.mu.Unlock()
+
+  //
+  // Gather up candles from each day that overlaps the requested time range until we have as many as
+  // were asked for.
+  //
+  ret := make([]*file.Candle, 0)
+
+  for day := start.UTC().Truncate(constants.OneDay); !day.After(end) && len(ret) < limit; day = day.Add(constants.OneDay) {
+    candles, err := o.loadDay(symbol, interval, day)
+    if err != nil {
+      return nil, err
+    }
+
+    for _, c := range candles {
+      if len(ret) >= limit {
+        break
+      }
+
+      if !c.StartTime().Before(start) && !c.StartTime().After(end) {
+        ret = append(ret, c)
+      }
+    }
+  }
+
+  return file.NewResponse(ret), nil
+}
+
+func (o *Cache) RetrieveSymbol(source string, dest string) string {
+  return o.source.RetrieveSymbol(source, dest)
+}
+
+//
+// loadDay retrieves the specified day of candles, reading it from disk if it has been cached and
+// fetching it otherwise.
+//
+func (o *Cache) loadDay(symbol string, interval exchange.Interval, day time.Time) ([]*file.Candle, error) {
+  //
+  // Days that are not over yet are always fetched fresh.
+  //
+  if !o.complete(day, interval) {
+    return o.fetchDay(symbol, interval, day)
+  }
+
+  //
+  // Re-use the most recently-read day if it is the one being asked for again (as is common when
+  // consecutive requests cover less than a day each).
+  //
+  key := fmt.Sprintf("%s/%s", symbol, interval)
+  path := o.path(symbol, interval, day)
+
+  if o.lastPath[key] == path {
+    return o.lastCandles[key], nil
+  }
+
+  //
+  // Read the day from disk, or fetch it if it has not been cached yet.
+  //
+  var candles []*file.Candle
+  var err error
+
+  if _, statErr := os.Stat(path); statErr == nil {
+    candles, err = file.Read(path)
+  } else {
+    candles, err = o.fetchDay(symbol, interval, day)
+  }
+
+  if err != nil {
+    return nil, err
+  }
+
+  o.lastPath[key] = path
+  o.lastCandles[key] = candles
+
+  return candles, nil
+}
+
+//
+// fetchDay pages through the underlying candle source to retrieve every candle of the specified
+// interval for the specified symbol that starts within the specified day. If the day is over, the
+// candles are also saved to disk.
+//
+func (o *Cache) fetchDay(symbol string, interval exchange.Interval, day time.Time) ([]*file.Candle, error) {
+  dayEnd := day.Add(constants.OneDay).Add(-time.Millisecond)
+  candles := make([]exchange.Candle, 0)
+
+  //
+  // Retrieve the day's candles one page at a time.
+  //
+  for cursor := day; !cursor.After(dayEnd); {
+    resp, err := o.source.RetrieveCandles(symbol, interval, cursor, dayEnd, PageSize)
+    if err != nil {
+      return nil, fmt.Errorf("failed to fetch %s %s candles starting at %s (%s)", symbol, interval, cursor, err)
+    }
+
+    page := resp.Candles()
+    candles = append(candles, page...)
+
+    if len(page) < PageSize {
+      break
+    }
+
+    cursor = page[len(page)-1].StartTime().Add(time.Millisecond)
+  }
+
+  //
+  // Save the day to disk if it is over. We write to a temporary file first so that an interrupted
+  // write can never leave a partial day behind.
+  //
+  if o.complete(day, interval) {
+    path := o.path(symbol, interval, day)
+    partialPath := path[:len(path)-len(file.CSVExt)] + ".partial" + file.CSVExt
+
+    if err := file.Write(partialPath, candles); err != nil {
+      return nil, err
+    }
+
+    if err := os.Rename(partialPath, path); err != nil {
+      return nil, err
+    }
+
+    logger.Printf("Cached %d %s %s candles for %s.", len(candles), symbol, interval, day.Format(DayFmt))
+  }
+
+  //
+  // Convert the candles into the form that is read back from disk so that callers get the same thing
+  // regardless of whether or not the day was cached.
+  //
+  ret := make([]*file.Candle, len(candles))
+
+  for i, c := range candles {
+    ret[i] = file.CopyCandle(c)
+  }
+
+  return ret, nil
+}
+
+//
+// complete returns whether or not every candle of the specified interval that starts within the
+// specified day has closed out.
+//
+func (o *Cache) complete(day time.Time, interval exchange.Interval) bool {
+  return day.Add(constants.OneDay).Add(interval.Duration()).Before(time.Now())
+}
+
+//
+// path returns where the specified day of candles is cached.
+//
+func (o *Cache) path(symbol string, interval exchange.Interval, day time.Time) string {
+  return filepath.Join(o.dir, o.exchange, symbol, interval.String(), day.Format(DayFmt)+file.CSVExt)
+}
@@ -0,0 +1,28 @@
+package exchange
+
+import (
+  "time"
+)
+
+//
+// CandleSource generically provides an interface to an object that historical candles can be
+// retrieved from. Normally, this is an exchange's REST API client, but it may also be something like
+// a set of local files.
+//
+type CandleSource interface {
+
+  //
+  // RetrieveCandles retrieves candles of the specified interval for the specified ticker symbol
+  // within the specified time range. A maximum of the specified limit of candles will be returned
+  // (note that many exchanges impose a hard maximum on the limit – usually at around 1000 candles).
+  //
+  RetrieveCandles(symbol string, interval Interval, start time.Time, end time.Time, limit int) (Response, error)
+
+  //
+  // RetrieveSymbol retrieves or generates the appropriate symbol for the provided source and
+  // destination assets. For example, Coinbase Pro's "BTC-USD" market might be the equivelant of
+  // Binance.US' "BTCUSD" market.
+  //
+  RetrieveSymbol(source string, dest string) string
+
+}
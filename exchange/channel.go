@@ -0,0 +1,17 @@
+package exchange
+
+//
+// Channel is an enum that represents a category of messages that can be subscribed to over an
+// exchange's live websocket feed.
+//
+type Channel int
+
+const (
+  TradeChannel     Channel = iota // Individual trades (a.k.a. matches) that occur on a market.
+  KlineChannel                    // One minute candlesticks (a.k.a. klines) as they close out.
+  HeartbeatChannel                // Periodic messages that indicate that the feed is still alive.
+)
+
+func (o Channel) String() string {
+  return [...]string{"trade", "kline", "heartbeat"}[o]
+}
@@ -1,7 +1,7 @@
 package exchange
 
 import (
-  "time"
+  "github.com/shopspring/decimal"
 )
 
 //
@@ -14,6 +14,7 @@ import (
 // that was received will be returned.
 //
 type Client interface {
+  CandleSource
 
   //
   // Auth provides the relevant exchange's API key and secret to the client. Some implementations
@@ -24,17 +25,37 @@ type Client interface {
   Auth(key string, secret string) (Response, error)
 
   //
-  // RetrieveCandles retrieves candles of the specified interval for the specified ticker symbol
-  // within the specified time range. A maximum of the specified limit of candles will be returned
-  // (note that many exchanges impose a hard maximum on the limit – usually at around 1000 candles).
+  // PlaceMarketOrder places an order for the specified quantity of the base asset of the specified
+  // market that will be executed immediately at the best price available.
   //
-  RetrieveCandles(symbol string, interval Interval, start time.Time, end time.Time, limit int) (Response, error)
+  PlaceMarketOrder(symbol string, side OrderSide, quantity decimal.Decimal) (Response, error)
 
   //
-  // RetrieveSymbol retrieves or generates the appropriate symbol for the provided source and
-  // destination assets. For example, Coinbase Pro's "BTC-USD" market might be the equivelant of
-  // Binance.US' "BTCUSD" market.
+  // PlaceLimitOrder places an order for the specified quantity of the base asset of the specified
+  // market that will only be executed at the specified price or better. How long the order remains
+  // on the order book is dictated by the specified time in force.
   //
-  RetrieveSymbol(source string, dest string) string
+  PlaceLimitOrder(
+      symbol string,
+      side OrderSide,
+      quantity decimal.Decimal,
+      price decimal.Decimal,
+      timeInForce TimeInForce,
+  ) (Response, error)
+
+  //
+  // CancelOrder cancels the specified order (if it has not already been completely filled).
+  //
+  CancelOrder(symbol string, orderID string) (Response, error)
+
+  //
+  // RetrieveOrder retrieves the current status of the specified order.
+  //
+  RetrieveOrder(symbol string, orderID string) (Response, error)
+
+  //
+  // RetrieveBalances retrieves how much of each asset the authenticated account holds.
+  //
+  RetrieveBalances() (Response, error)
 
 }
@@ -0,0 +1,7 @@
+package coinbasepro
+
+const (
+  FeedURL = "wss://ws-feed.pro.coinbase.com"
+
+  EventBufferSize = 256
+)
@@ -0,0 +1,197 @@
+package coinbasepro
+
+import (
+  "errors"
+  "fmt"
+  "github.com/lukehollenback/goose/exchange"
+  "github.com/shopspring/decimal"
+  "sync"
+
+  ws "github.com/gorilla/websocket"
+  cbp "github.com/preichenberger/go-coinbasepro/v2"
+)
+
+var (
+  channelNames = map[exchange.Channel]string{
+    exchange.TradeChannel:     "matches",
+    exchange.HeartbeatChannel: "heartbeat",
+  }
+)
+
+//
+// WSClient implements the exchange.WSClient interface for the Coinbase Pro websocket feed.
+//
+type WSClient struct {
+  mu       *sync.Mutex
+  conn     *ws.Conn
+  chEvents chan *exchange.Event
+  closing  bool
+}
+
+func NewWSClient() *WSClient {
+  return &WSClient{
+    mu: &sync.Mutex{},
+  }
+}
+
+func (o *WSClient) Connect() error {
+  o.mu.Lock()
+  defer o.mu.Unlock()
+
+  //
+  // Connect to the Coinbase Pro websocket feed.
+  //
+  var wsDialer ws.Dialer
+
+  conn, _, err := wsDialer.Dial(FeedURL, nil)
+  if err != nil {
+    return err
+  }
+
+  //
+  // (Re)initialize our instance variables and begin reading messages off of the new connection.
+  //
+  o.conn = conn
+  o.chEvents = make(chan *exchange.Event, EventBufferSize)
+  o.closing = false
+
+  go o.read(o.conn, o.chEvents)
+
+  return nil
+}
+
+func (o *WSClient) Subscribe(symbol string, channels ...exchange.Channel) error {
+  o.mu.Lock()
+  defer o.mu.Unlock()
+
+  if o.conn == nil {
+    return errors.New("cannot subscribe before connecting to the Coinbase Pro websocket feed")
+  }
+
+  //
+  // Build out the subscription message.
+  //
+  subscribe := cbp.Message{
+    Type:     "subscribe",
+    Channels: make([]cbp.MessageChannel, 0, len(channels)),
+  }
+
+  for _, channel := range channels {
+    name, ok := channelNames[channel]
+    if !ok {
+      return fmt.Errorf("the Coinbase Pro websocket feed does not support the %s channel", channel)
+    }
+
+    subscribe.Channels = append(subscribe.Channels, cbp.MessageChannel{
+      Name:       name,
+      ProductIds: []string{symbol},
+    })
+  }
+
+  //
+  // Send the subscription message.
+  //
+  return o.conn.WriteJSON(subscribe)
+}
+
+func (o *WSClient) Events() <-chan *exchange.Event {
+  o.mu.Lock()
+  defer o.mu.Unlock()
+
+  return o.chEvents
+}
+
+func (o *WSClient) Close() error {
+  o.mu.Lock()
+  defer o.mu.Unlock()
+
+  if o.conn == nil {
+    return nil
+  }
+
+  o.closing = true
+
+  return o.conn.Close()
+}
+
+func (o *WSClient) RetrieveSymbol(source string, dest string) string {
+  return fmt.Sprintf("%s-%s", source, dest)
+}
+
+//
+// read continuously reads messages from the provided connection and converts them into events
+// until the connection fails or is closed.
+//
+func (o *WSClient) read(conn *ws.Conn, chEvents chan<- *exchange.Event) {
+  defer close(chEvents)
+
+  for {
+    //
+    // Read the next message. If the read fails because we intentionally closed the connection, we
+    // simply stop. Otherwise, we report the failure.
+    //
+    msg := &cbp.Message{}
+
+    if err := conn.ReadJSON(msg); err != nil {
+      o.mu.Lock()
+      closing := o.closing
+      o.mu.Unlock()
+
+      if !closing {
+        chEvents <- &exchange.Event{Type: exchange.ErrorEvent, Err: err}
+      }
+
+      return
+    }
+
+    //
+    // Convert the message into an event (if it is one that we care about).
+    //
+    if event, err := convert(msg); err != nil {
+      chEvents <- &exchange.Event{Type: exchange.ErrorEvent, Err: err}
+    } else if event != nil {
+      chEvents <- event
+    }
+  }
+}
+
+//
+// convert translates the provided Coinbase Pro message into an exchange-agnostic event. If the
+// message is not one that we care about, nil is returned.
+//
+func convert(msg *cbp.Message) (*exchange.Event, error) {
+  switch msg.Type {
+  case "subscriptions":
+    return &exchange.Event{Type: exchange.SubscribedEvent}, nil
+
+  case "heartbeat":
+    return &exchange.Event{Type: exchange.HeartbeatEvent, Symbol: msg.ProductID, Time: msg.Time.Time()}, nil
+
+  case "match", "last_match":
+    // NOTE ~> The "last_match" message is sent immediately after subscribing to the "matches"
+    //  channel and describes the most recent trade that occurred prior to the subscription.
+
+    price, err := decimal.NewFromString(msg.Price)
+    if err != nil {
+      return nil, fmt.Errorf("failed to parse price from message (Message: %+v) (Error: %s)", msg, err)
+    }
+
+    size, err := decimal.NewFromString(msg.Size)
+    if err != nil {
+      return nil, fmt.Errorf("failed to parse size from message (Message: %+v) (Error: %s)", msg, err)
+    }
+
+    return &exchange.Event{
+      Type:   exchange.TradeEvent,
+      Symbol: msg.ProductID,
+      Time:   msg.Time.Time(),
+      Price:  price,
+      Size:   size,
+    }, nil
+
+  case "error":
+    return nil, fmt.Errorf("the Coinbase Pro websocket feed reported an error (%s: %s)", msg.Message, msg.Reason)
+  }
+
+  return nil, nil
+}
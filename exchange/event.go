@@ -0,0 +1,38 @@
+package exchange
+
+import (
+  "github.com/shopspring/decimal"
+  "time"
+)
+
+//
+// EventType is an enum that represents the kind of message that was received over an exchange's
+// live websocket feed.
+//
+type EventType int
+
+const (
+  SubscribedEvent EventType = iota // A subscription request has been acknowledged.
+  TradeEvent                       // A trade has occurred.
+  KlineEvent                       // A candlestick has closed out.
+  HeartbeatEvent                   // The feed has indicated that it is still alive.
+  ErrorEvent                       // The feed has reported an error or the connection has failed.
+)
+
+func (o EventType) String() string {
+  return [...]string{"Subscribed", "Trade", "Kline", "Heartbeat", "Error"}[o]
+}
+
+//
+// Event represents a single, exchange-agnostic message that was received over an exchange's live
+// websocket feed. Which fields are populated depends on the type of the event.
+//
+type Event struct {
+  Type   EventType
+  Symbol string          // The market that the event pertains to (if any).
+  Time   time.Time       // The instant that the event occurred according to the exchange.
+  Price  decimal.Decimal // The price of the trade (trade events only).
+  Size   decimal.Decimal // The quantity of the base asset that was traded (trade events only).
+  Candle Candle          // The candlestick that closed out (kline events only).
+  Err    error           // What went wrong (error events only).
+}
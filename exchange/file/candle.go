@@ -0,0 +1,70 @@
+package file
+
+import (
+  "github.com/lukehollenback/goose/exchange"
+  "github.com/shopspring/decimal"
+  "time"
+)
+
+//
+// Candle implements the exchange.Candle interface for candlesticks (a.k.a. klines) that have been
+// loaded from a local file.
+//
+type Candle struct {
+  start  time.Time
+  end    time.Time
+  open   decimal.Decimal
+  high   decimal.Decimal
+  low    decimal.Decimal
+  close  decimal.Decimal
+  volume decimal.Decimal
+  count  int
+}
+
+func (o *Candle) StartTime() *time.Time {
+  return &o.start
+}
+
+func (o *Candle) EndTime() *time.Time {
+  return &o.end
+}
+
+func (o *Candle) Open() *decimal.Decimal {
+  return &o.open
+}
+
+func (o *Candle) High() *decimal.Decimal {
+  return &o.high
+}
+
+func (o *Candle) Low() *decimal.Decimal {
+  return &o.low
+}
+
+func (o *Candle) Close() *decimal.Decimal {
+  return &o.close
+}
+
+func (o *Candle) Volume() *decimal.Decimal {
+  return &o.volume
+}
+
+func (o *Candle) Count() *int {
+  return &o.count
+}
+
+//
+// CopyCandle copies the values of any exchange-provided candle into a new candle.
+//
+func CopyCandle(c exchange.Candle) *Candle {
+  return &Candle{
+    start:  *c.StartTime(),
+    end:    *c.EndTime(),
+    open:   *c.Open(),
+    high:   *c.High(),
+    low:    *c.Low(),
+    close:  *c.Close(),
+    volume: *c.Volume(),
+    count:  *c.Count(),
+  }
+}
@@ -0,0 +1,353 @@
+package file
+
+import (
+  "bufio"
+  "encoding/csv"
+  "encoding/json"
+  "fmt"
+  "github.com/lukehollenback/goose/exchange"
+  "github.com/shopspring/decimal"
+  "io"
+  "os"
+  "path/filepath"
+  "sort"
+  "strconv"
+  "strings"
+  "time"
+)
+
+// NOTE ~> Candle files are either CSV files with a header row, or JSON-lines files with one object
+//  per line. Both use the same field names:
+//
+//  start   The opening instant of the candle (Unix milliseconds or RFC 3339).
+//  end     The closing instant of the candle (Unix milliseconds or RFC 3339). Optional.
+//  open    The opening price of the candle.
+//  high    The high price of the candle.
+//  low     The low price of the candle.
+//  close   The closing price of the candle.
+//  volume  The trade volume of the candle. Optional.
+//  count   The transaction count of the candle. Optional.
+//
+//  If the end of a candle is not provided, it is assumed to be one millisecond before the start of
+//  the next candle (as is the case with candles provided by Binance.US), where the spacing between
+//  candles is taken to be the smallest gap between any two consecutive start times in the file.
+
+const (
+  CSVExt       = ".csv"
+  JSONLinesExt = ".jsonl"
+
+  MillisInNano = 1000000
+)
+
+var (
+  csvHeader = []string{"start", "end", "open", "high", "low", "close", "volume", "count"}
+)
+
+//
+// Read loads the candles held by the specified file. Whether the file is parsed as CSV or JSON-lines
+// is determined by its extension. The returned candles are sorted by their start times.
+//
+func Read(path string) ([]*Candle, error) {
+  f, err := os.Open(path)
+  if err != nil {
+    return nil, err
+  }
+
+  defer f.Close()
+
+  switch strings.ToLower(filepath.Ext(path)) {
+  case CSVExt:
+    return ReadCSV(f)
+  case JSONLinesExt, ".json":
+    return ReadJSONLines(f)
+  }
+
+  return nil, fmt.Errorf("cannot determine the format of candle file %s", path)
+}
+
+//
+// ReadCSV parses candles from the provided CSV data. The first row must be a header row naming the
+// columns. The returned candles are sorted by their start times.
+//
+func ReadCSV(r io.Reader) ([]*Candle, error) {
+  reader := csv.NewReader(r)
+
+  //
+  // Determine which column holds which field from the header row.
+  //
+  header, err := reader.Read()
+  if err != nil {
+    return nil, fmt.Errorf("failed to read header row (%s)", err)
+  }
+
+  columns := make(map[string]int)
+
+  for i, name := range header {
+    columns[strings.ToLower(strings.TrimSpace(name))] = i
+  }
+
+  for _, name := range []string{"start", "open", "high", "low", "close"} {
+    if _, ok := columns[name]; !ok {
+      return nil, fmt.Errorf("missing required %s column", name)
+    }
+  }
+
+  field := func(row []string, name string) string {
+    if i, ok := columns[name]; ok && i < len(row) {
+      return strings.TrimSpace(row[i])
+    }
+
+    return ""
+  }
+
+  //
+  // Parse each of the remaining rows into a candle.
+  //
+  candles := make([]*Candle, 0)
+
+  for line := 2; ; line++ {
+    row, err := reader.Read()
+    if err == io.EOF {
+      break
+    } else if err != nil {
+      return nil, err
+    }
+
+    c, err := parseCandle(
+      field(row, "start"), field(row, "end"), field(row, "open"), field(row, "high"),
+      field(row, "low"), field(row, "close"), field(row, "volume"), field(row, "count"),
+    )
+    if err != nil {
+      return nil, fmt.Errorf("failed to parse line %d (%s)", line, err)
+    }
+
+    candles = append(candles, c)
+  }
+
+  return finalize(candles), nil
+}
+
+//
+// ReadJSONLines parses candles from the provided JSON-lines data. Blank lines are skipped. The
+// returned candles are sorted by their start times.
+//
+func ReadJSONLines(r io.Reader) ([]*Candle, error) {
+  scanner := bufio.NewScanner(r)
+  candles := make([]*Candle, 0)
+
+  for line := 1; scanner.Scan(); line++ {
+    if strings.TrimSpace(scanner.Text()) == "" {
+      continue
+    }
+
+    //
+    // Unmarshall the line into raw values. Every field is accepted as either a JSON string or a
+    // JSON number.
+    //
+    var raw map[string]json.RawMessage
+
+    if err := json.Unmarshal(scanner.Bytes(), &raw); err != nil {
+      return nil, fmt.Errorf("failed to parse line %d (%s)", line, err)
+    }
+
+    field := func(name string) string {
+      return strings.Trim(string(raw[name]), `"`)
+    }
+
+    c, err := parseCandle(
+      field("start"), field("end"), field("open"), field("high"),
+      field("low"), field("close"), field("volume"), field("count"),
+    )
+    if err != nil {
+      return nil, fmt.Errorf("failed to parse line %d (%s)", line, err)
+    }
+
+    candles = append(candles, c)
+  }
+
+  if err := scanner.Err(); err != nil {
+    return nil, err
+  }
+
+  return finalize(candles), nil
+}
+
+//
+// Write saves the provided candles to the specified file (creating any missing parent directories
+// along the way). Whether the file is written as CSV or JSON-lines is determined by its extension.
+//
+func Write(path string, candles []exchange.Candle) error {
+  if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+    return err
+  }
+
+  f, err := os.Create(path)
+  if err != nil {
+    return err
+  }
+
+  switch strings.ToLower(filepath.Ext(path)) {
+  case CSVExt:
+    err = WriteCSV(f, candles)
+  case JSONLinesExt:
+    err = WriteJSONLines(f, candles)
+  default:
+    err = fmt.Errorf("cannot determine the format of candle file %s", path)
+  }
+
+  if closeErr := f.Close(); err == nil {
+    err = closeErr
+  }
+
+  return err
+}
+
+//
+// WriteCSV writes the provided candles out as CSV data (including a header row).
+//
+func WriteCSV(w io.Writer, candles []exchange.Candle) error {
+  writer := csv.NewWriter(w)
+
+  if err := writer.Write(csvHeader); err != nil {
+    return err
+  }
+
+  for _, c := range candles {
+    err := writer.Write([]string{
+      formatTime(*c.StartTime()), formatTime(*c.EndTime()), c.Open().String(), c.High().String(),
+      c.Low().String(), c.Close().String(), c.Volume().String(), strconv.Itoa(*c.Count()),
+    })
+    if err != nil {
+      return err
+    }
+  }
+
+  writer.Flush()
+
+  return writer.Error()
+}
+
+//
+// WriteJSONLines writes the provided candles out as JSON-lines data.
+//
+func WriteJSONLines(w io.Writer, candles []exchange.Candle) error {
+  encoder := json.NewEncoder(w)
+
+  for _, c := range candles {
+    err := encoder.Encode(map[string]interface{}{
+      "start":  c.StartTime().UnixNano() / MillisInNano,
+      "end":    c.EndTime().UnixNano() / MillisInNano,
+      "open":   c.Open().String(),
+      "high":   c.High().String(),
+      "low":    c.Low().String(),
+      "close":  c.Close().String(),
+      "volume": c.Volume().String(),
+      "count":  *c.Count(),
+    })
+    if err != nil {
+      return err
+    }
+  }
+
+  return nil
+}
+
+//
+// parseCandle builds a candle out of the provided raw field values. Empty optional values are
+// defaulted.
+//
+func parseCandle(start, end, open, high, low, close, volume, count string) (*Candle, error) {
+  var err error
+
+  c := &Candle{}
+
+  if c.start, err = parseTime(start); err != nil {
+    return nil, fmt.Errorf("invalid start (%s)", err)
+  }
+
+  if end != "" {
+    if c.end, err = parseTime(end); err != nil {
+      return nil, fmt.Errorf("invalid end (%s)", err)
+    }
+  }
+
+  if c.open, err = decimal.NewFromString(open); err != nil {
+    return nil, fmt.Errorf("invalid open (%s)", err)
+  }
+
+  if c.high, err = decimal.NewFromString(high); err != nil {
+    return nil, fmt.Errorf("invalid high (%s)", err)
+  }
+
+  if c.low, err = decimal.NewFromString(low); err != nil {
+    return nil, fmt.Errorf("invalid low (%s)", err)
+  }
+
+  if c.close, err = decimal.NewFromString(close); err != nil {
+    return nil, fmt.Errorf("invalid close (%s)", err)
+  }
+
+  if volume != "" {
+    if c.volume, err = decimal.NewFromString(volume); err != nil {
+      return nil, fmt.Errorf("invalid volume (%s)", err)
+    }
+  }
+
+  if count != "" {
+    if c.count, err = strconv.Atoi(count); err != nil {
+      return nil, fmt.Errorf("invalid count (%s)", err)
+    }
+  }
+
+  return c, nil
+}
+
+//
+// parseTime parses the provided timestamp, which may either be in Unix milliseconds or RFC 3339
+// format.
+//
+func parseTime(s string) (time.Time, error) {
+  if millis, err := strconv.ParseInt(s, 10, 64); err == nil {
+    return time.Unix(0, millis*MillisInNano).UTC(), nil
+  }
+
+  return time.Parse(time.RFC3339Nano, s)
+}
+
+//
+// formatTime formats the provided timestamp in Unix milliseconds.
+//
+func formatTime(t time.Time) string {
+  return strconv.FormatInt(t.UnixNano()/MillisInNano, 10)
+}
+
+//
+// finalize sorts the provided candles by their start times and fills in any missing end times.
+//
+func finalize(candles []*Candle) []*Candle {
+  sort.Slice(candles, func(i, j int) bool {
+    return candles[i].start.Before(candles[j].start)
+  })
+
+  //
+  // Determine the spacing between candles.
+  //
+  var step time.Duration
+
+  for i := 1; i < len(candles); i++ {
+    if gap := candles[i].start.Sub(candles[i-1].start); gap > 0 && (step == 0 || gap < step) {
+      step = gap
+    }
+  }
+
+  //
+  // Fill in any missing end times.
+  //
+  for _, c := range candles {
+    if c.end.IsZero() && step > 0 {
+      c.end = c.start.Add(step).Add(-time.Millisecond)
+    }
+  }
+
+  return candles
+}
@@ -0,0 +1,49 @@
+package file
+
+import (
+  "github.com/lukehollenback/goose/exchange"
+  "net/http"
+)
+
+//
+// Response implements the exchange.Response interface for candles that have been loaded from local
+// files. As no endpoint was actually called, only the candles are meaningful.
+//
+type Response struct {
+  candles []*Candle
+}
+
+//
+// NewResponse wraps the provided candles in a response.
+//
+func NewResponse(candles []*Candle) *Response {
+  return &Response{
+    candles: candles,
+  }
+}
+
+func (o *Response) Raw() *http.Response {
+  return nil
+}
+
+func (o *Response) Body() []byte {
+  return nil
+}
+
+func (o *Response) Candles() []exchange.Candle {
+  ret := make([]exchange.Candle, len(o.candles))
+
+  for i, v := range o.candles {
+    ret[i] = v
+  }
+
+  return ret
+}
+
+func (o *Response) Order() exchange.Order {
+  return nil
+}
+
+func (o *Response) Balances() []exchange.Balance {
+  return nil
+}
@@ -0,0 +1,240 @@
+package file
+
+import (
+  "fmt"
+  "github.com/lukehollenback/goose/exchange"
+  "os"
+  "path/filepath"
+  "sync"
+  "time"
+)
+
+//
+// CandleSource implements the exchange.CandleSource interface for candles held by local CSV or
+// JSON-lines files. It can either be pointed at a single file, in which case the file's candles are
+// used for every symbol, or at a directory, in which case candles are loaded from files laid out as
+// "<directory>/<symbol>/<interval>.csv" (or ".jsonl").
+//
+// If candles of a requested interval are not available, but candles of a shorter interval that
+// evenly divides it are (e.g. one minute candles when fifteen minute candles are requested), they
+// are aggregated into candles of the requested interval.
+//
+type CandleSource struct {
+  mu     *sync.Mutex
+  path   string
+  isDir  bool
+  series map[string][]*Candle // Candles that have already been loaded, keyed by symbol and interval.
+}
+
+//
+// NewCandleSource instantiates a new candle source that loads candles from the specified file or
+// directory.
+//
+func NewCandleSource(path string) (*CandleSource, error) {
+  info, err := os.Stat(path)
+  if err != nil {
+    return nil, err
+  }
+
+  return &CandleSource{
+    mu:     &sync.Mutex{},
+    path:   path,
+    isDir:  info.IsDir(),
+    series: make(map[string][]*Candle),
+  }, nil
+}
+
+func (o *CandleSource) RetrieveCandles(
+    symbol string,
+    interval exchange.Interval,
+    start time.Time,
+    end time.Time,
+    limit int,
+) (exchange.Response, error) {
+  o.mu.Lock()
+  defer o.mu.Unlock()
+
+  //
+  // Load all of the candles of the requested interval.
+  //
+  candles, err := o.load(symbol, interval)
+  if err != nil {
+    return nil, err
+  }
+
+  //
+  // Pick out the candles that start within the requested time range.
+  //
+  ret := make([]*Candle, 0)
+
+  for _, c := range candles {
+    if len(ret) >= limit {
+      break
+    }
+
+    if !c.start.Before(start) && !c.start.After(end) {
+      ret = append(ret, c)
+    }
+  }
+
+  return &Response{candles: ret}, nil
+}
+
+func (o *CandleSource) RetrieveSymbol(source string, dest string) string {
+  return fmt.Sprintf("%s%s", source, dest)
+}
+
+//
+// load retrieves all of the candles of the specified interval for the specified symbol, loading
+// them from disk (and aggregating them if necessary) the first time that they are asked for.
+//
+func (o *CandleSource) load(symbol string, interval exchange.Interval) ([]*Candle, error) {
+  key := fmt.Sprintf("%s/%s", symbol, interval)
+
+  if candles, ok := o.series[key]; ok {
+    return candles, nil
+  }
+
+  //
+  // Find the file holding the candles with the longest interval that can be used to build candles
+  // of the requested interval. For a single file, that is always the file itself.
+  //
+  var candles []*Candle
+  var err error
+
+  if o.isDir {
+    candles, err = o.loadFromDir(symbol, interval)
+  } else {
+    candles, err = o.loadFromFile(interval)
+  }
+
+  if err != nil {
+    return nil, err
+  }
+
+  o.series[key] = candles
+
+  return candles, nil
+}
+
+//
+// loadFromDir loads candles of the specified interval for the specified symbol from the source's
+// directory, aggregating candles of a shorter interval if necessary.
+//
+func (o *CandleSource) loadFromDir(symbol string, interval exchange.Interval) ([]*Candle, error) {
+  for i := interval; i >= exchange.OneMinute; i-- {
+    if i != interval && (i.Duration() == 0 || interval.Duration()%i.Duration() != 0) {
+      continue
+    }
+
+    for _, ext := range []string{CSVExt, JSONLinesExt} {
+      path := filepath.Join(o.path, symbol, i.String()+ext)
+
+      if _, err := os.Stat(path); err != nil {
+        continue
+      }
+
+      candles, err := Read(path)
+      if err != nil {
+        return nil, fmt.Errorf("failed to read candle file %s (%s)", path, err)
+      }
+
+      if i == interval {
+        return candles, nil
+      }
+
+      return Aggregate(candles, i.Duration(), interval.Duration()), nil
+    }
+  }
+
+  return nil, fmt.Errorf("no candle files that can provide %s candles exist for %s in %s", interval, symbol, o.path)
+}
+
+//
+// loadFromFile loads candles from the source's single file and aggregates them into candles of the
+// specified interval if necessary.
+//
+func (o *CandleSource) loadFromFile(interval exchange.Interval) ([]*Candle, error) {
+  candles, err := Read(o.path)
+  if err != nil {
+    return nil, fmt.Errorf("failed to read candle file %s (%s)", o.path, err)
+  }
+
+  if len(candles) == 0 {
+    return candles, nil
+  }
+
+  //
+  // Determine the interval of the candles held by the file and make sure that it can be used to
+  // build candles of the requested interval.
+  //
+  // NOTE ~> Candles usually end just before the next one starts (e.g. one millisecond before), so we
+  //  round up to the nearest second.
+  //
+  base := candles[0].end.Sub(candles[0].start).Round(time.Second)
+
+  if base == interval.Duration() {
+    return candles, nil
+  }
+
+  if base <= 0 || interval.Duration() == 0 || interval.Duration()%base != 0 {
+    return nil, fmt.Errorf("%s candles cannot be built from the %s candles held by %s", interval, base, o.path)
+  }
+
+  return Aggregate(candles, base, interval.Duration()), nil
+}
+
+//
+// Aggregate combines the provided candles, which are of the specified base interval, into candles
+// of the specified (longer) interval. The resulting candles are aligned to the Unix epoch (e.g.
+// one hour candles start at the top of each hour).
+//
+func Aggregate(candles []*Candle, base time.Duration, interval time.Duration) []*Candle {
+  ret := make([]*Candle, 0, len(candles)*int(base)/int(interval)+1)
+
+  var cur *Candle
+
+  for _, c := range candles {
+    //
+    // Start a new candle whenever we cross into a new interval.
+    //
+    // NOTE ~> The end of the aggregated candle is offset from the end of its interval by the same
+    //  amount that the base candles' ends are offset from theirs.
+    //
+    bucket := c.start.Truncate(interval)
+
+    if cur == nil || !cur.start.Equal(bucket) {
+      cur = &Candle{
+        start:  bucket,
+        end:    bucket.Add(interval).Add(c.end.Sub(c.start) - base),
+        open:   c.open,
+        high:   c.high,
+        low:    c.low,
+        close:  c.close,
+        volume: c.volume,
+        count:  c.count,
+      }
+
+      ret = append(ret, cur)
+
+      continue
+    }
+
+    //
+    // Otherwise, fold the base candle into the current candle.
+    //
+    if c.high.GreaterThan(cur.high) {
+      cur.high = c.high
+    }
+
+    if c.low.LessThan(cur.low) {
+      cur.low = c.low
+    }
+
+    cur.close = c.close
+    cur.volume = cur.volume.Add(c.volume)
+    cur.count += c.count
+  }
+
+  return ret
+}
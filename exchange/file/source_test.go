@@ -0,0 +1,113 @@
+package file
+
+import (
+  "github.com/lukehollenback/goose/exchange"
+  "github.com/shopspring/decimal"
+  "io/ioutil"
+  "os"
+  "path/filepath"
+  "testing"
+  "time"
+)
+
+//
+// writeTempFile writes the provided contents out to a temporary file with the specified name and
+// returns its path. The returned function removes the file.
+//
+func writeTempFile(t *testing.T, name string, contents string) (string, func()) {
+  dir, err := ioutil.TempDir("", "goose")
+  if err != nil {
+    t.Fatalf("Failed to create temporary directory. (Error: %s)", err)
+  }
+
+  path := filepath.Join(dir, name)
+
+  if err := ioutil.WriteFile(path, []byte(contents), 0644); err != nil {
+    t.Fatalf("Failed to write temporary file. (Error: %s)", err)
+  }
+
+  return path, func() { _ = os.RemoveAll(dir) }
+}
+
+func TestRetrieveAggregatedCandlesFromCSV(t *testing.T) {
+  //
+  // Write out six one minute candles and load them up.
+  //
+  path, cleanup := writeTempFile(t, "candles.csv", ""+
+      "start,end,open,high,low,close,volume,count\n"+
+      "1598313600000,1598313659999,100,110,90,105,1,10\n"+
+      "1598313660000,1598313719999,105,120,100,115,1,10\n"+
+      "1598313720000,1598313779999,115,115,80,85,1,10\n"+
+      "1598313780000,1598313839999,85,90,85,90,1,10\n"+
+      "1598313840000,1598313899999,90,95,90,95,1,10\n"+
+      "1598313900000,1598313959999,95,100,95,100,1,10\n")
+  defer cleanup()
+
+  source, err := NewCandleSource(path)
+  if err != nil {
+    t.Fatalf("Failed to instantiate candle source. (Error: %s)", err)
+  }
+
+  //
+  // Retrieve five minute candles and make sure that they were properly aggregated.
+  //
+  start := time.Unix(1598313600, 0)
+
+  resp, err := source.RetrieveCandles("BTCUSD", exchange.FiveMinute, start, start.Add(time.Hour), 1000)
+  if err != nil {
+    t.Fatalf("Failed to retrieve candles. (Error: %s)", err)
+  }
+
+  candles := resp.Candles()
+
+  if len(candles) != 2 {
+    t.Fatalf("Expected 2 five minute candles but instead got %d.", len(candles))
+  }
+
+  first := candles[0]
+
+  if !first.Open().Equal(decimal.NewFromInt(100)) || !first.Close().Equal(decimal.NewFromInt(95)) ||
+      !first.High().Equal(decimal.NewFromInt(120)) || !first.Low().Equal(decimal.NewFromInt(80)) {
+    t.Errorf(
+      "Expected first candle to be (O: 100, C: 95, H: 120, L: 80) but was instead (O: %s, C: %s, H: %s, L: %s).",
+      first.Open(), first.Close(), first.High(), first.Low(),
+    )
+  }
+
+  if !first.Volume().Equal(decimal.NewFromInt(5)) || *first.Count() != 50 {
+    t.Errorf("Expected first candle to have a volume of 5 and a count of 50.")
+  }
+
+  if expected := start.Add(5 * time.Minute).Add(-time.Millisecond); !first.EndTime().Equal(expected) {
+    t.Errorf("Expected first candle to end at %s but instead ended at %s.", expected, first.EndTime())
+  }
+}
+
+func TestRetrieveCandlesFromJSONLines(t *testing.T) {
+  //
+  // Write out two one minute candles (without end times) and load them up.
+  //
+  path, cleanup := writeTempFile(t, "candles.jsonl", ""+
+      `{"start": "2020-08-25T00:00:00Z", "open": "100", "high": "110", "low": "90", "close": "105"}`+"\n"+
+      `{"start": 1598313660000, "open": 105, "high": 120, "low": 100, "close": 115}`+"\n")
+  defer cleanup()
+
+  source, err := NewCandleSource(path)
+  if err != nil {
+    t.Fatalf("Failed to instantiate candle source. (Error: %s)", err)
+  }
+
+  //
+  // Retrieve only the second candle.
+  //
+  start := time.Unix(1598313660, 0)
+
+  resp, err := source.RetrieveCandles("BTCUSD", exchange.OneMinute, start, start.Add(time.Hour), 1000)
+  if err != nil {
+    t.Fatalf("Failed to retrieve candles. (Error: %s)", err)
+  }
+
+  if candles := resp.Candles(); len(candles) != 1 || !candles[0].Close().Equal(decimal.NewFromInt(115)) {
+    t.Errorf("Expected exactly one candle closing at 115.")
+  }
+}
@@ -1,5 +1,10 @@
 package exchange
 
+import (
+  "fmt"
+  "time"
+)
+
 //
 // Interval is an enum that represents various kline/candlestick intervals that can be retrieved
 // from an exchange's historical data endpoints.
@@ -27,3 +32,28 @@ const (
 func (o Interval) String() string {
   return [...]string{"1m", "3m", "5m", "15m", "30m", "1h", "2h", "4h", "6h", "8h", "12h", "1d", "3d", "1w", "1M"}[o]
 }
+
+//
+// Duration returns how much time a candle of the interval spans. Zero is returned for intervals that
+// do not span a fixed amount of time (i.e. months).
+//
+func (o Interval) Duration() time.Duration {
+  return [...]time.Duration{
+    time.Minute, 3 * time.Minute, 5 * time.Minute, 15 * time.Minute, 30 * time.Minute,
+    time.Hour, 2 * time.Hour, 4 * time.Hour, 6 * time.Hour, 8 * time.Hour, 12 * time.Hour,
+    24 * time.Hour, 3 * 24 * time.Hour, 7 * 24 * time.Hour, 0,
+  }[o]
+}
+
+//
+// ParseInterval returns the interval that is represented by the provided string (e.g. "5m").
+//
+func ParseInterval(s string) (Interval, error) {
+  for i := OneMinute; i <= OneMonth; i++ {
+    if i.String() == s {
+      return i, nil
+    }
+  }
+
+  return OneMinute, fmt.Errorf("unknown interval %s", s)
+}
@@ -0,0 +1,75 @@
+package exchange
+
+import (
+  "github.com/shopspring/decimal"
+  "time"
+)
+
+//
+// Order generically provides an interface to objects that represent orders that have been placed on
+// an exchange, as provided in a response from a call to an exchange's API endpoint.
+//
+type Order interface {
+
+  //
+  // ID returns the identifier that the exchange assigned to the order.
+  //
+  ID() string
+
+  //
+  // Symbol returns the market symbol that the order was placed on.
+  //
+  Symbol() string
+
+  //
+  // Side returns which side of the order book the order was placed on.
+  //
+  Side() OrderSide
+
+  //
+  // Type returns how the order is to be executed by the exchange.
+  //
+  Type() OrderType
+
+  //
+  // Status returns the state that the order was in at the time of the response.
+  //
+  Status() OrderStatus
+
+  //
+  // TimeInForce returns how long the order will remain active on the order book. It is only
+  // meaningful for limit orders.
+  //
+  TimeInForce() TimeInForce
+
+  //
+  // Time returns a pointer to the structure representing the instant that the order was placed or
+  // last updated.
+  //
+  Time() *time.Time
+
+  //
+  // Price returns a pointer to the structure representing the limit price of the order. It will be
+  // zero for market orders.
+  //
+  Price() *decimal.Decimal
+
+  //
+  // Quantity returns a pointer to the structure representing the requested quantity of the base
+  // asset.
+  //
+  Quantity() *decimal.Decimal
+
+  //
+  // FilledQuantity returns a pointer to the structure representing the quantity of the base asset
+  // that has been executed so far.
+  //
+  FilledQuantity() *decimal.Decimal
+
+  //
+  // FilledQuoteQuantity returns a pointer to the structure representing the quantity of the quote
+  // asset that has been spent or received by the executions so far.
+  //
+  FilledQuoteQuantity() *decimal.Decimal
+
+}
@@ -0,0 +1,15 @@
+package exchange
+
+//
+// OrderSide is an enum that represents the side of the order book that an order is placed on.
+//
+type OrderSide int
+
+const (
+  Buy OrderSide = iota
+  Sell
+)
+
+func (o OrderSide) String() string {
+  return [...]string{"BUY", "SELL"}[o]
+}
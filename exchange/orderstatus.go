@@ -0,0 +1,28 @@
+package exchange
+
+//
+// OrderStatus is an enum that represents the state that an order is in as reported by an exchange.
+//
+type OrderStatus int
+
+const (
+  New OrderStatus = iota
+  PartiallyFilled
+  Filled
+  Canceled
+  PendingCancel
+  Rejected
+  Expired
+)
+
+func (o OrderStatus) String() string {
+  return [...]string{"NEW", "PARTIALLY_FILLED", "FILLED", "CANCELED", "PENDING_CANCEL", "REJECTED", "EXPIRED"}[o]
+}
+
+//
+// Final returns whether or not the status indicates that the order will never change again (e.g. it
+// has been completely filled or has been canceled).
+//
+func (o OrderStatus) Final() bool {
+  return o == Filled || o == Canceled || o == Rejected || o == Expired
+}
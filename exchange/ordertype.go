@@ -0,0 +1,16 @@
+package exchange
+
+//
+// OrderType is an enum that represents how an order should be executed by an exchange's matching
+// engine.
+//
+type OrderType int
+
+const (
+  Market OrderType = iota
+  Limit
+)
+
+func (o OrderType) String() string {
+  return [...]string{"MARKET", "LIMIT"}[o]
+}
@@ -26,4 +26,16 @@ type Response interface {
   //
   Candles() []Candle
 
+  //
+  // Order provides the order that was placed, canceled, or retrieved by the endpoint call that was
+  // made (if there was one).
+  //
+  Order() Order
+
+  //
+  // Balances provides a slice of the asset balances returned from the endpoint call that was made
+  // (if there were any).
+  //
+  Balances() []Balance
+
 }
\ No newline at end of file
@@ -0,0 +1,17 @@
+package exchange
+
+//
+// TimeInForce is an enum that represents how long a limit order should remain active on an
+// exchange's order book before it expires.
+//
+type TimeInForce int
+
+const (
+  GoodTillCanceled  TimeInForce = iota // The order rests on the book until it is filled or canceled.
+  ImmediateOrCancel                    // Whatever can be filled immediately is filled, and the rest is canceled.
+  FillOrKill                           // The order is either filled completely and immediately, or not at all.
+)
+
+func (o TimeInForce) String() string {
+  return [...]string{"GTC", "IOC", "FOK"}[o]
+}
@@ -1,9 +1,41 @@
 package exchange
 
 //
-// Client generically provides an interface to an object that can be used to interact with a
+// WSClient generically provides an interface to an object that can be used to interact with a
 // cryptocurrency exchange's live websocket feed.
 //
 type WSClient interface {
 
+  //
+  // Connect establishes a connection to the exchange's websocket feed and begins producing events
+  // from it. Each call creates a brand-new event stream, so the client can be reconnected after the
+  // connection fails.
+  //
+  Connect() error
+
+  //
+  // Subscribe requests that messages of the specified channels for the specified market symbol be
+  // sent over the feed. Acknowledgement of the subscription is provided as an event. An error will
+  // be returned if the exchange does not support one of the specified channels.
+  //
+  Subscribe(symbol string, channels ...Channel) error
+
+  //
+  // Events returns the stream of events that are being received over the current connection. An
+  // error event is produced and the stream is closed if the connection fails.
+  //
+  Events() <-chan *Event
+
+  //
+  // Close closes the connection to the exchange's websocket feed. No error event is produced for a
+  // connection that was intentionally closed.
+  //
+  Close() error
+
+  //
+  // RetrieveSymbol retrieves or generates the appropriate symbol for the provided source and
+  // destination assets as expected by the feed.
+  //
+  RetrieveSymbol(source string, dest string) string
+
 }
@@ -0,0 +1,47 @@
+package main
+
+import (
+  "github.com/lukehollenback/goose/constants"
+  "github.com/lukehollenback/goose/exchange"
+  "github.com/lukehollenback/goose/exchange/cache"
+  "log"
+  "time"
+)
+
+//
+// fetch downloads historical candles of the specified interval for the specified asset within the
+// specified time range into the provided cache. Days that have already been cached are skipped.
+//
+func fetch(candleCache *cache.Cache, asset string, intervalStr string, startStr string, endStr string) {
+  //
+  // Parse the parameters of the fetch.
+  //
+  interval, err := exchange.ParseInterval(intervalStr)
+  if err != nil {
+    log.Fatalf("Failed to parse the fetch interval. (Error: %s)", err)
+  }
+
+  start, err := time.Parse(constants.TimestampFmt, startStr)
+  if err != nil {
+    log.Fatalf("Failed to parse the fetch start timestamp. (Error: %s)", err)
+  }
+
+  end, err := time.Parse(constants.TimestampFmt, endStr)
+  if err != nil {
+    log.Fatalf("Failed to parse the fetch end timestamp. (Error: %s)", err)
+  }
+
+  //
+  // Actually fetch the candles.
+  //
+  symbol := candleCache.RetrieveSymbol(asset, "USD")
+
+  log.Printf("Fetching %s %s candles from %s through %s...", symbol, interval, start, end)
+
+  fetched, err := candleCache.Fetch(symbol, interval, start, end)
+  if err != nil {
+    log.Fatalf("Failed to fetch candles. (Error: %s)", err)
+  }
+
+  log.Printf("Fetched %d day(s) of candles. All other days were already cached.", fetched)
+}
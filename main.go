@@ -3,16 +3,28 @@ package main
 import (
   "flag"
   "fmt"
+  "github.com/lukehollenback/goose/exchange"
   "github.com/lukehollenback/goose/exchange/binance"
-  "github.com/lukehollenback/goose/trader/algos/movingaverages"
+  "github.com/lukehollenback/goose/exchange/cache"
+  "github.com/lukehollenback/goose/exchange/coinbasepro"
+  _ "github.com/lukehollenback/goose/trader/algos/bollinger"
+  _ "github.com/lukehollenback/goose/trader/algos/donchian"
+  _ "github.com/lukehollenback/goose/trader/algos/macd"
+  _ "github.com/lukehollenback/goose/trader/algos/movingaverages"
+  _ "github.com/lukehollenback/goose/trader/algos/rsi"
   "github.com/lukehollenback/goose/trader/broker"
   "github.com/lukehollenback/goose/trader/candle"
   "github.com/lukehollenback/goose/trader/monitor"
+  "github.com/lukehollenback/goose/trader/report"
+  "github.com/lukehollenback/goose/trader/risk"
+  "github.com/lukehollenback/goose/trader/strategy"
   "github.com/lukehollenback/goose/trader/writer"
   "github.com/shopspring/decimal"
   "log"
   "os"
   "os/signal"
+  "path/filepath"
+  "strings"
 )
 
 func main() {
@@ -42,10 +54,61 @@ func main() {
     fmt.Sprintf("The secret to use for signing requests when interacting with the Binance.US API."),
   )
 
-  cfgAsset := flag.String(
+  cfgFeed := flag.String(
+    "feed",
+    "coinbasepro",
+    fmt.Sprintf("The exchange whose live websocket feed should be monitored. Valid values are coinbasepro and binance."),
+  )
+
+  cfgAssets := flag.String(
     "asset",
     "BTC",
-    fmt.Sprintf("The asset that should be traded."),
+    fmt.Sprintf("A comma-separated list of the assets that should be traded (e.g. BTC,ETH,XLM)."),
+  )
+
+  cfgStrategies := flag.String(
+    "strategy",
+    "movingaverages",
+    fmt.Sprintf(
+      "A comma-separated list of the strategies that should be run. Available strategies are: %s.",
+      strings.Join(strategy.Names(), ", "),
+    ),
+  )
+
+  cfgQtyPrecision := flag.Int(
+    "quantity-precision",
+    6,
+    fmt.Sprintf(
+      "The number of decimals that order quantities of the asset should be truncated to. This must not be "+
+          "finer than the exchange's step size for the market.",
+    ),
+  )
+
+  cfgCacheDir := flag.String(
+    "cache-dir",
+    defaultCacheDir(),
+    fmt.Sprintf(
+      "The directory that historical candles should be cached in so that backtests do not need to re-download "+
+          "them. Caching is disabled if empty.",
+    ),
+  )
+
+  cfgFetchInterval := flag.String(
+    "fetch-interval",
+    "1m",
+    fmt.Sprintf("The interval of candles that the fetch command should download (e.g. 1m, 5m, 1h, or 1d)."),
+  )
+
+  cfgFetchStart := flag.String(
+    "fetch-start",
+    "2006-01-02 03:04",
+    fmt.Sprintf("The timestamp that the fetch command should start downloading candles at."),
+  )
+
+  cfgFetchEnd := flag.String(
+    "fetch-end",
+    "2006-01-02 03:04",
+    fmt.Sprintf("The timestamp that the fetch command should stop downloading candles at."),
   )
 
   cfgMock := flag.Bool(
@@ -66,8 +129,22 @@ func main() {
     fmt.Sprintf("The maker/taker fee that each mock trade costs to execute."),
   )
 
+  cfgMockMakerFee := flag.Float64(
+    "mock-maker-fee",
+    -1,
+    fmt.Sprintf("The fee that each mock fill which rested on the order book costs. Defaults to -mock-fee if negative."),
+  )
+
+  cfgMockTakerFee := flag.Float64(
+    "mock-taker-fee",
+    -1,
+    fmt.Sprintf("The fee that each mock fill which took from the order book costs. Defaults to -mock-fee if negative."),
+  )
+
   flag.Parse()
 
+  assets := splitList(*cfgAssets)
+
   //
   // Instantiate and authenticate with a financial exchange's REST API.
   //
@@ -75,9 +152,34 @@ func main() {
   _, _ = client.Auth(*cfgBinanceAPIKey, *cfgBinanceAPISecret)
 
   //
-  // Start the desired algorithm(s).
+  // If we have been asked to simply fetch historical candles into the cache, do so and then bail
+  // out. None of the services need to be started.
+  //
+  if flag.Arg(0) == "fetch" {
+    if *cfgCacheDir == "" {
+      log.Fatalf("The fetch command requires a cache directory.")
+    }
+
+    for _, asset := range assets {
+      fetch(cache.New(*cfgCacheDir, "binance", client), asset, *cfgFetchInterval, *cfgFetchStart, *cfgFetchEnd)
+    }
+
+    return
+  }
+
+  //
+  // Instantiate a client for the desired exchange's live websocket feed.
   //
-  movingaverages.Init()
+  var wsClient exchange.WSClient
+
+  switch *cfgFeed {
+  case "coinbasepro":
+    wsClient = coinbasepro.NewWSClient()
+  case "binance":
+    wsClient = binance.NewWSClient()
+  default:
+    log.Fatalf("Unknown websocket feed %s. Valid values are coinbasepro and binance.", *cfgFeed)
+  }
 
   //
   // Start up the Writer Service.
@@ -98,10 +200,23 @@ func main() {
   //
   // Start up the Broker Service.
   //
-  broker.Instance().SetAsset(*cfgAsset)
+  broker.Instance().SetClient(client)
+  broker.Instance().SetAssets(assets)
+  broker.Instance().SetQuantityPrecision(int32(*cfgQtyPrecision))
 
   if *cfgMock {
-    broker.Instance().EnableMockTrading(decimal.NewFromInt(*cfgMockAmt), decimal.NewFromFloat(*cfgMockFee))
+    makerFee := decimal.NewFromFloat(*cfgMockFee)
+    takerFee := decimal.NewFromFloat(*cfgMockFee)
+
+    if *cfgMockMakerFee >= 0 {
+      makerFee = decimal.NewFromFloat(*cfgMockMakerFee)
+    }
+
+    if *cfgMockTakerFee >= 0 {
+      takerFee = decimal.NewFromFloat(*cfgMockTakerFee)
+    }
+
+    broker.Instance().EnableMockTrading(decimal.NewFromInt(*cfgMockAmt), makerFee, takerFee)
   }
 
   chBrokerStarted, err := broker.Instance().Start()
@@ -113,7 +228,74 @@ func main() {
   // Start up the Monitor Service.
   //
   monitor.Instance().SetClient(client)
-  monitor.Instance().SetAsset(*cfgAsset)
+
+  if *cfgCacheDir != "" {
+    monitor.Instance().SetCandleSource(cache.New(*cfgCacheDir, "binance", client))
+  }
+
+  monitor.Instance().SetWSClient(wsClient)
+  monitor.Instance().SetAssets(assets)
+
+  //
+  // Have the risk manager check its limits whenever candles close so that they trip even when no
+  // strategies are emitting signals.
+  //
+  monitor.Instance().RegisterCandleCloseHandler(risk.Instance().Evaluate)
+
+  //
+  // Provide the Broker Service with the candles that it needs to track the volatility of each market
+  // for position sizing. This is done before any strategies subscribe so that volatility is always
+  // up to date by the time that they emit signals.
+  //
+  for _, asset := range assets {
+    asset := asset
+
+    _, err := monitor.Instance().Subscribe(asset, broker.Instance().ATRInterval(), func(c *candle.Candle) {
+      broker.Instance().ObserveCandle(asset, c)
+    })
+    if err != nil {
+      log.Fatalf("Failed to subscribe the broker service to %s candles. (Error: %s)", asset, err)
+    }
+  }
+
+  //
+  // Start the desired strategies. Each market gets its own instance of each strategy. They must
+  // subscribe to the candles that they need before the Monitor Service starts producing them.
+  //
+  for _, asset := range assets {
+    for _, name := range splitList(*cfgStrategies) {
+      s, err := strategy.New(name)
+      if err != nil {
+        log.Fatalf("Failed to instantiate a strategy. (Error: %s)", err)
+      }
+
+      if _, err := strategy.Run(s, asset); err != nil {
+        log.Fatalf("Failed to run the %s strategy for %s. (Error: %s)", s.Name(), asset, err)
+      }
+    }
+  }
+
+  //
+  // When backtesting, have the Report Service sample the portfolio as one minute candles close (after
+  // the strategies have acted upon them) and report on its performance once the backtest completes.
+  //
+  if monitor.Instance().Backtesting() {
+    report.Instance().SetAssets(assets)
+
+    for _, asset := range assets {
+      asset := asset
+
+      _, err := monitor.Instance().Subscribe(asset, candle.OneMin, func(c *candle.Candle) {
+        report.Instance().Observe(asset, c)
+      })
+      if err != nil {
+        log.Fatalf("Failed to subscribe the report service to %s candles. (Error: %s)", asset, err)
+      }
+    }
+
+    monitor.Instance().RegisterBacktestCompleteHandler(report.Instance().Complete)
+  }
+
   chMonitorStarted, err := monitor.Instance().Start()
   if err != nil {
     log.Fatalf("Failed to start the match monitor service. (Error: %s)", err)
@@ -167,3 +349,31 @@ func main() {
   //
   log.Print("Goodbye.")
 }
+
+//
+// defaultCacheDir determines where historical candles should be cached by default. If the operating
+// system does not provide a user cache directory, caching is disabled by default.
+//
+func defaultCacheDir() string {
+  dir, err := os.UserCacheDir()
+  if err != nil {
+    return ""
+  }
+
+  return filepath.Join(dir, "goose")
+}
+
+//
+// splitList splits a comma-separated flag value into its trimmed, non-empty elements.
+//
+func splitList(value string) []string {
+  elements := make([]string, 0)
+
+  for _, element := range strings.Split(value, ",") {
+    if element = strings.TrimSpace(element); element != "" {
+      elements = append(elements, element)
+    }
+  }
+
+  return elements
+}
@@ -0,0 +1,337 @@
+package bollinger
+
+import (
+  "flag"
+  "fmt"
+  "github.com/logrusorgru/aurora"
+  "github.com/lukehollenback/goose/constants"
+  "github.com/lukehollenback/goose/trader/broker"
+  "github.com/lukehollenback/goose/trader/candle"
+  "github.com/lukehollenback/goose/trader/indicators"
+  "github.com/lukehollenback/goose/trader/strategy"
+  "github.com/shopspring/decimal"
+  "log"
+  "time"
+)
+
+const (
+  Name         = "≪bollinger≫"
+  StrategyName = "bollinger"
+
+  Breakout      = "breakout"      // Enter when a close breaks out above the bands after a squeeze.
+  MeanReversion = "meanreversion" // Enter when a close comes back inside the bands from below them.
+)
+
+var (
+  logger *log.Logger
+
+  cfgPrecision    *int
+  cfgPeriod       *int
+  cfgLength       *int
+  cfgMultiplier   *float64
+  cfgMode         *string
+  cfgSqueezeWidth *float64
+)
+
+func init() {
+  //
+  // Initialize the logger.
+  //
+  logger = log.New(log.Writer(), fmt.Sprintf(constants.LogPrefixFmt, Name), log.Ldate|log.Ltime|log.Lmsgprefix)
+
+  //
+  // Register and parse configuration flags.
+  //
+  cfgPrecision = flag.Int(
+    "bb-precision",
+    8,
+    fmt.Sprintf(
+      "The number of decimals to which the bands of asset values should be rounded to by the %s algorithm. "+
+          "Common values include 8 (1 Satoshi) for BTC and 7 (1 Stroop) for XLM.",
+      Name,
+    ),
+  )
+
+  cfgPeriod = flag.Int(
+    "bb-period",
+    5,
+    fmt.Sprintf(
+      "The period length (in minutes) that the %s algorithm should watch (e.g. 5, 60, or 1440).",
+      Name,
+    ),
+  )
+
+  cfgLength = flag.Int(
+    "bb-length",
+    20,
+    fmt.Sprintf(
+      "The length (in periods) of the moving average and standard deviation that the bands of the %s "+
+          "algorithm are calculated from.",
+      Name,
+    ),
+  )
+
+  cfgMultiplier = flag.Float64(
+    "bb-multiplier",
+    2,
+    fmt.Sprintf(
+      "The number of standard deviations that the upper and lower bands of the %s algorithm are away from "+
+          "the middle band.",
+      Name,
+    ),
+  )
+
+  cfgMode = flag.String(
+    "bb-mode",
+    Breakout,
+    fmt.Sprintf(
+      "How the %s algorithm enters positions. Valid values are %s (on a close above the upper band after a "+
+          "squeeze, exiting on a close below the middle band) and %s (on a close back inside the lower band, "+
+          "exiting on a close back inside the upper band).",
+      Name, Breakout, MeanReversion,
+    ),
+  )
+
+  cfgSqueezeWidth = flag.Float64(
+    "bb-squeeze-width",
+    0.04,
+    fmt.Sprintf(
+      "The width of the bands (as a fraction of the middle band) at or below which the %s algorithm "+
+          "considers them to be squeezed. Only used in %s mode.",
+      Name, Breakout,
+    ),
+  )
+
+  //
+  // Make the algorithm available to be run.
+  //
+  strategy.Register(StrategyName, func() (strategy.Strategy, error) {
+    return NewFromFlags()
+  })
+}
+
+//
+// Algo represents an instance of the Bollinger Band algorithm. It implements the Strategy
+// interface.
+//
+type Algo struct {
+  period time.Duration // The interval of candles that the algorithm watches.
+
+  candleCnt int // The number of candles that have been provided to the algorithm.
+
+  length       int                   // Length of the moving average that the bands are calculated from.
+  multiplier   decimal.Decimal       // The number of standard deviations that the bands are away from the middle band.
+  mode         string                // How the algorithm enters positions (either Breakout or MeanReversion).
+  squeezeWidth decimal.Decimal       // The width of the bands at or below which they are considered squeezed.
+  lastSignal   broker.Signal         // The last signal that was fired by the algorithm.
+  bands        *indicators.Bollinger // The Bollinger Bands of candle closes.
+  prevClose    decimal.Decimal       // The close of the previous candle.
+  prevUpper    decimal.Decimal       // The upper band as of the previous candle.
+  prevLower    decimal.Decimal       // The lower band as of the previous candle.
+  prevWidth    decimal.Decimal       // The width of the bands as of the previous candle, or negative one if not yet calculated.
+}
+
+//
+// New instantiates a new, independent instance of the algorithm. The period is specified in minutes
+// and may be any whole number of them.
+//
+func New(period int, length int, multiplier float64, mode string, squeezeWidth float64) (*Algo, error) {
+  //
+  // Make sure that the configuration makes sense.
+  //
+  if err := candle.ValidateInterval(time.Duration(period) * time.Minute); err != nil {
+    return nil, fmt.Errorf("invalid period of %d minutes (%s)", period, err)
+  }
+
+  if mode != Breakout && mode != MeanReversion {
+    return nil, fmt.Errorf("unknown mode %s (valid values are %s and %s)", mode, Breakout, MeanReversion)
+  }
+
+  if squeezeWidth < 0 {
+    return nil, fmt.Errorf("the squeeze width (%g) must not be negative", squeezeWidth)
+  }
+
+  bands, err := indicators.NewBollinger(length, decimal.NewFromFloat(multiplier), int32(*cfgPrecision))
+  if err != nil {
+    return nil, err
+  }
+
+  //
+  // Instantiate the algorithm.
+  //
+  o := &Algo{
+    period: time.Duration(period) * time.Minute,
+
+    length:       length,
+    multiplier:   decimal.NewFromFloat(multiplier),
+    mode:         mode,
+    squeezeWidth: decimal.NewFromFloat(squeezeWidth),
+    lastSignal:   broker.None,
+    bands:        bands,
+    prevClose:    decimal.Zero,
+    prevUpper:    decimal.Zero,
+    prevLower:    decimal.Zero,
+    prevWidth:    constants.NegOne(),
+  }
+
+  //
+  // Log some debug info.
+  //
+  logger.Printf(
+    "Initialized. (Period = %d minutes, Length = %d periods, Multiplier = %s, Mode = %s, Squeeze Width = %s).",
+    period, o.length, o.multiplier, o.mode, o.squeezeWidth,
+  )
+
+  return o, nil
+}
+
+//
+// NewFromFlags instantiates a new, independent instance of the algorithm as configured by the
+// command line flags.
+//
+func NewFromFlags() (*Algo, error) {
+  return New(*cfgPeriod, *cfgLength, *cfgMultiplier, *cfgMode, *cfgSqueezeWidth)
+}
+
+//
+// Name implements the Strategy interface's described method.
+//
+func (o *Algo) Name() string {
+  return StrategyName
+}
+
+//
+// Intervals implements the Strategy interface's described method.
+//
+func (o *Algo) Intervals() []time.Duration {
+  return []time.Duration{o.period}
+}
+
+//
+// WarmUp implements the Strategy interface's described method. The bands need one extra period
+// beyond their length so that previous bands exist to compare against.
+//
+func (o *Algo) WarmUp() int {
+  return o.length + 1
+}
+
+//
+// OnCandle implements the Strategy interface's described method. It adds the newly-closed candle
+// that is provided to it to the algorithm's bands and returns any signal that the candle's close
+// triggers relative to them.
+//
+func (o *Algo) OnCandle(newCandle *candle.Candle) broker.Signal {
+  //
+  // Update the bands with the newly-closed candle, remembering where things stood as of the
+  // previous candle once we are done.
+  //
+  o.candleCnt++
+
+  closeAmt := newCandle.CloseAmt()
+
+  o.bands.Add(closeAmt)
+
+  defer func() {
+    o.prevClose = closeAmt
+
+    if o.bands.Ready() {
+      o.prevUpper = o.bands.Upper()
+      o.prevLower = o.bands.Lower()
+      o.prevWidth = o.bands.Width()
+    }
+  }()
+
+  //
+  // Determine if a signal should be fired. If we do not yet have both current and previous bands,
+  // we must skip this step as the algorithm is not warmed up enough.
+  //
+  if !o.bands.Ready() || o.prevWidth.Equal(constants.NegOne()) {
+    logger.Printf(
+      "Not warmed up yet (%d/%d data points collected). The bands have not yet been calculated twice.",
+      o.candleCnt,
+      o.WarmUp(),
+    )
+
+    return broker.None
+  }
+
+  if o.mode == Breakout {
+    return o.breakout(closeAmt)
+  }
+
+  return o.meanReversion(closeAmt)
+}
+
+//
+// breakout determines the signal (if any) that the provided close triggers in breakout mode. A
+// close above the upper band while the bands were squeezed indicates the start of a volatile move
+// (a "buy" opportunity), and a close back below the middle band indicates that it has run its
+// course (a "sell" opportunity).
+//
+func (o *Algo) breakout(closeAmt decimal.Decimal) broker.Signal {
+  squeezed := !o.prevWidth.GreaterThan(o.squeezeWidth)
+
+  if squeezed && closeAmt.GreaterThan(o.bands.Upper()) && o.lastSignal != broker.UptrendDetected {
+    logger.Printf(
+      "Close (%s) has broken out ABOVE upper band (%s) after a squeeze (width %s). This is a %s signal!",
+      closeAmt, o.bands.Upper(), o.prevWidth, aurora.Bold(aurora.Green("BUY")),
+    )
+
+    return o.emitSignal(broker.UptrendDetected)
+  }
+
+  if o.lastSignal == broker.UptrendDetected && closeAmt.LessThan(o.bands.Middle()) {
+    logger.Printf(
+      "Close (%s) has fallen BELOW middle band (%s). This is a %s signal!",
+      closeAmt, o.bands.Middle(), aurora.Bold(aurora.Red("SELL")),
+    )
+
+    return o.emitSignal(broker.DowntrendDetected)
+  }
+
+  return broker.None
+}
+
+//
+// meanReversion determines the signal (if any) that the provided close triggers in mean-reversion
+// mode. A close back inside the lower band indicates that an oversold move has started to revert
+// (a "buy" opportunity), and a close back inside the upper band indicates that an overbought move
+// has started to revert (a "sell" opportunity).
+//
+// NOTE ~> Closes are compared against the bands as of the same candle, so the previous close is
+//  compared against the previous bands.
+//
+func (o *Algo) meanReversion(closeAmt decimal.Decimal) broker.Signal {
+  backAboveLower := o.prevClose.LessThan(o.prevLower) && !closeAmt.LessThan(o.bands.Lower())
+  backBelowUpper := o.prevClose.GreaterThan(o.prevUpper) && !closeAmt.GreaterThan(o.bands.Upper())
+
+  if backAboveLower && o.lastSignal != broker.UptrendDetected {
+    logger.Printf(
+      "Close (%s) has come back ABOVE lower band (%s). This is a %s signal!",
+      closeAmt, o.bands.Lower(), aurora.Bold(aurora.Green("BUY")),
+    )
+
+    return o.emitSignal(broker.UptrendDetected)
+  }
+
+  if backBelowUpper && o.lastSignal == broker.UptrendDetected {
+    logger.Printf(
+      "Close (%s) has come back BELOW upper band (%s). This is a %s signal!",
+      closeAmt, o.bands.Upper(), aurora.Bold(aurora.Red("SELL")),
+    )
+
+    return o.emitSignal(broker.DowntrendDetected)
+  }
+
+  return broker.None
+}
+
+//
+// emitSignal returns the specified signal so that it can be routed to the Broker Service, caching
+// it in case we want to refer back to it at any point (e.g. in tests or user interfaces).
+//
+func (o *Algo) emitSignal(signal broker.Signal) broker.Signal {
+  o.lastSignal = signal
+
+  return signal
+}
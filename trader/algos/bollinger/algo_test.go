@@ -0,0 +1,144 @@
+package bollinger
+
+import (
+  "github.com/lukehollenback/goose/trader/broker"
+  "github.com/lukehollenback/goose/trader/candle"
+  "github.com/shopspring/decimal"
+  "testing"
+  "time"
+)
+
+const (
+  FiveMinutes = 5 * time.Minute
+)
+
+var (
+  now = time.Now()
+)
+
+//
+// seedAlgo provides the algorithm with candles that close at each of the provided values, returning
+// the signal that the last one triggered.
+//
+func seedAlgo(o *Algo, closes ...int64) broker.Signal {
+  signal := broker.None
+
+  for _, v := range closes {
+    // NOTE ~> We do not care about the timestamp each of these candles are tagged too. We are not
+    //  testing candle stores here.
+
+    data := candle.CreateCandle(now, FiveMinutes, decimal.NewFromInt(v))
+
+    signal = o.OnCandle(data)
+  }
+
+  return signal
+}
+
+func TestFiveMinuteBreakoutAfterSqueeze(t *testing.T) {
+  //
+  // Instantiate the algorithm.
+  //
+  o, err := New(5, 10, 2, Breakout, 0.01)
+  if err != nil {
+    t.Fatalf("Failed to instantiate the algorithm. (Error: %s)", err)
+  }
+
+  //
+  // Seed the algorithm with a flat market, which squeezes the bands down to nothing.
+  //
+  seedAlgo(o, 100, 100, 100, 100, 100, 100, 100, 100, 100, 100)
+
+  if !o.bands.Width().IsZero() {
+    t.Fatalf("Expected a flat market to squeeze the bands but their width was %s.", o.bands.Width())
+  }
+
+  //
+  // Simulate a breakout. The middle band moves to 102 and the upper band to 114.
+  //
+  seedAlgo(o, 120)
+
+  if !o.bands.Upper().Equal(decimal.NewFromInt(114)) {
+    t.Errorf("Expected the upper band to be 114 but was instead %s.", o.bands.Upper())
+  }
+
+  if o.lastSignal != broker.UptrendDetected {
+    t.Errorf(
+      "Expected an \"Uptrend Detected\" (signal %d) signal to have been fired, but instead a %d signal was.",
+      broker.UptrendDetected, o.lastSignal,
+    )
+  }
+
+  //
+  // Simulate the move fading back below the middle band.
+  //
+  seedAlgo(o, 100)
+
+  if o.lastSignal != broker.DowntrendDetected {
+    t.Errorf(
+      "Expected an \"Downtrend Detected\" (signal %d) signal to have been fired, but instead a %d signal was.",
+      broker.DowntrendDetected, o.lastSignal,
+    )
+  }
+}
+
+func TestFiveMinuteBreakoutRequiresSqueeze(t *testing.T) {
+  o, err := New(5, 10, 2, Breakout, 0.01)
+  if err != nil {
+    t.Fatalf("Failed to instantiate the algorithm. (Error: %s)", err)
+  }
+
+  //
+  // Seed the algorithm with a choppy market whose bands are far wider than the squeeze width.
+  //
+  seedAlgo(o, 90, 110, 90, 110, 90, 110, 90, 110, 90, 110)
+
+  if signal := seedAlgo(o, 140); signal != broker.None {
+    t.Errorf("Expected no signal from a close above the bands without a squeeze, but got signal %d.", signal)
+  }
+}
+
+func TestFiveMinuteMeanReversion(t *testing.T) {
+  //
+  // Instantiate the algorithm.
+  //
+  o, err := New(5, 5, 1, MeanReversion, 0)
+  if err != nil {
+    t.Fatalf("Failed to instantiate the algorithm. (Error: %s)", err)
+  }
+
+  //
+  // Seed the algorithm with a flat market and then a drop below the lower band (of 94).
+  //
+  if signal := seedAlgo(o, 100, 100, 100, 100, 100, 90); signal != broker.None {
+    t.Fatalf("Expected no signal while falling below the lower band, but got signal %d.", signal)
+  }
+
+  //
+  // Simulate a close back inside the lower band.
+  //
+  seedAlgo(o, 100)
+
+  if o.lastSignal != broker.UptrendDetected {
+    t.Errorf(
+      "Expected an \"Uptrend Detected\" (signal %d) signal to have been fired, but instead a %d signal was.",
+      broker.UptrendDetected, o.lastSignal,
+    )
+  }
+
+  //
+  // Simulate a spike above the upper band (of 109) and then a close back inside it.
+  //
+  if signal := seedAlgo(o, 115); signal != broker.None {
+    t.Fatalf("Expected no signal while rising above the upper band, but got signal %d.", signal)
+  }
+
+  seedAlgo(o, 100)
+
+  if o.lastSignal != broker.DowntrendDetected {
+    t.Errorf(
+      "Expected an \"Downtrend Detected\" (signal %d) signal to have been fired, but instead a %d signal was.",
+      broker.DowntrendDetected, o.lastSignal,
+    )
+  }
+}
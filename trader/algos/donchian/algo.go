@@ -0,0 +1,271 @@
+package donchian
+
+import (
+  "flag"
+  "fmt"
+  "github.com/logrusorgru/aurora"
+  "github.com/lukehollenback/goose/constants"
+  "github.com/lukehollenback/goose/trader/broker"
+  "github.com/lukehollenback/goose/trader/candle"
+  "github.com/lukehollenback/goose/trader/indicators"
+  "github.com/lukehollenback/goose/trader/strategy"
+  "log"
+  "time"
+)
+
+const (
+  Name         = "≪donchian≫"
+  StrategyName = "donchian"
+)
+
+var (
+  logger *log.Logger
+
+  cfgPrecision *int
+  cfgPeriod    *int
+  cfgEntryLen  *int
+  cfgExitLen   *int
+  cfgATRLen    *int
+)
+
+func init() {
+  //
+  // Initialize the logger.
+  //
+  logger = log.New(log.Writer(), fmt.Sprintf(constants.LogPrefixFmt, Name), log.Ldate|log.Ltime|log.Lmsgprefix)
+
+  //
+  // Register and parse configuration flags.
+  //
+  cfgPrecision = flag.Int(
+    "dc-precision",
+    8,
+    fmt.Sprintf(
+      "The number of decimals to which the average true range of asset values should be rounded to by the "+
+          "%s algorithm. Common values include 8 (1 Satoshi) for BTC and 7 (1 Stroop) for XLM.",
+      Name,
+    ),
+  )
+
+  cfgPeriod = flag.Int(
+    "dc-period",
+    1440,
+    fmt.Sprintf(
+      "The period length (in minutes) that the %s algorithm should watch (e.g. 60, 240, or 1440).",
+      Name,
+    ),
+  )
+
+  cfgEntryLen = flag.Int(
+    "dc-entry-length",
+    20,
+    fmt.Sprintf(
+      "The length (in periods) of the channel whose high the %s algorithm enters positions on breaking "+
+          "above.",
+      Name,
+    ),
+  )
+
+  cfgExitLen = flag.Int(
+    "dc-exit-length",
+    10,
+    fmt.Sprintf(
+      "The length (in periods) of the channel whose low the %s algorithm exits positions on breaking below.",
+      Name,
+    ),
+  )
+
+  cfgATRLen = flag.Int(
+    "dc-atr-length",
+    20,
+    fmt.Sprintf(
+      "The length (in periods) of the average true range that the %s algorithm hints at the Broker Service "+
+          "to size positions against (when using atr sizing).",
+      Name,
+    ),
+  )
+
+  //
+  // Make the algorithm available to be run.
+  //
+  strategy.Register(StrategyName, func() (strategy.Strategy, error) {
+    return NewFromFlags()
+  })
+}
+
+//
+// Algo represents an instance of the Donchian channel breakout (i.e. "turtle") algorithm. It
+// implements the Strategy and Hinter interfaces.
+//
+type Algo struct {
+  period time.Duration // The interval of candles that the algorithm watches.
+
+  candleCnt int // The number of candles that have been provided to the algorithm.
+
+  entryLen   int                  // Length of the channel whose high is broken out above to enter.
+  exitLen    int                  // Length of the channel whose low is broken down below to exit.
+  atrLen     int                  // Length of the average true range that positions should be sized against.
+  lastSignal broker.Signal        // The last signal that was fired by the algorithm.
+  entry      *indicators.Donchian // The channel of the candles before the current one that entries are measured against.
+  exit       *indicators.Donchian // The channel of the candles before the current one that exits are measured against.
+  atr        *indicators.ATR      // The average true range of the market.
+}
+
+//
+// New instantiates a new, independent instance of the algorithm. The period is specified in minutes
+// and may be any whole number of them.
+//
+func New(period int, entryLen int, exitLen int, atrLen int) (*Algo, error) {
+  //
+  // Make sure that the configuration makes sense.
+  //
+  if err := candle.ValidateInterval(time.Duration(period) * time.Minute); err != nil {
+    return nil, fmt.Errorf("invalid period of %d minutes (%s)", period, err)
+  }
+
+  entry, err := indicators.NewDonchian(entryLen, int32(*cfgPrecision))
+  if err != nil {
+    return nil, err
+  }
+
+  exit, err := indicators.NewDonchian(exitLen, int32(*cfgPrecision))
+  if err != nil {
+    return nil, err
+  }
+
+  atr, err := indicators.NewATR(atrLen, int32(*cfgPrecision))
+  if err != nil {
+    return nil, err
+  }
+
+  //
+  // Instantiate the algorithm.
+  //
+  o := &Algo{
+    period: time.Duration(period) * time.Minute,
+
+    entryLen:   entryLen,
+    exitLen:    exitLen,
+    atrLen:     atrLen,
+    lastSignal: broker.None,
+    entry:      entry,
+    exit:       exit,
+    atr:        atr,
+  }
+
+  //
+  // Log some debug info.
+  //
+  logger.Printf(
+    "Initialized. (Period = %d minutes, Entry Channel = %d periods, Exit Channel = %d periods, ATR = %d "+
+        "periods).",
+    period, o.entryLen, o.exitLen, o.atrLen,
+  )
+
+  return o, nil
+}
+
+//
+// NewFromFlags instantiates a new, independent instance of the algorithm as configured by the
+// command line flags.
+//
+func NewFromFlags() (*Algo, error) {
+  return New(*cfgPeriod, *cfgEntryLen, *cfgExitLen, *cfgATRLen)
+}
+
+//
+// Name implements the Strategy interface's described method.
+//
+func (o *Algo) Name() string {
+  return StrategyName
+}
+
+//
+// Intervals implements the Strategy interface's described method.
+//
+func (o *Algo) Intervals() []time.Duration {
+  return []time.Duration{o.period}
+}
+
+//
+// WarmUp implements the Strategy interface's described method. Both channels must be full of the
+// candles that came before the one that is measured against them.
+//
+func (o *Algo) WarmUp() int {
+  if o.entryLen > o.exitLen {
+    return o.entryLen + 1
+  }
+
+  return o.exitLen + 1
+}
+
+//
+// SizeHint implements the Hinter interface's described method. It advises that positions be sized
+// against the average true range on the algorithm's own timeframe, as the turtles sized theirs
+// against "N". The hint is empty until the average true range is ready.
+//
+func (o *Algo) SizeHint() broker.SizeHint {
+  return broker.SizeHint{ATR: o.atr.Value()}
+}
+
+//
+// OnCandle implements the Strategy interface's described method. It measures the newly-closed
+// candle that is provided to it against the channels of the candles that came before it, adds it to
+// them, and returns any signal that a breakout triggered.
+//
+func (o *Algo) OnCandle(newCandle *candle.Candle) broker.Signal {
+  o.candleCnt++
+
+  high := newCandle.HighAmt()
+  low := newCandle.LowAmt()
+  signal := broker.None
+
+  //
+  // Determine if the candle broke out above the entry channel (indicating a "buy" opportunity) or
+  // down below the exit channel while we are in a position (indicating a "sell" opportunity). If
+  // either channel is not yet full, we must skip this step as the algorithm is not warmed up enough.
+  //
+  // NOTE ~> The channels do not yet include the candle, as a candle can never trade outside of a
+  //  range that includes itself.
+  //
+  if !o.entry.Ready() || !o.exit.Ready() {
+    logger.Printf(
+      "Not warmed up yet (%d/%d data points collected). The channels have not yet been filled.",
+      o.candleCnt,
+      o.WarmUp(),
+    )
+  } else if high.GreaterThan(o.entry.Upper()) && o.lastSignal != broker.UptrendDetected {
+    logger.Printf(
+      "High (%s) has broken out ABOVE the %d-period high (%s). This is a %s signal (at %s)!",
+      high, o.entryLen, o.entry.Upper(), aurora.Bold(aurora.Green("BUY")), newCandle.CloseAmt(),
+    )
+
+    signal = o.emitSignal(broker.UptrendDetected)
+  } else if low.LessThan(o.exit.Lower()) && o.lastSignal == broker.UptrendDetected {
+    logger.Printf(
+      "Low (%s) has broken down BELOW the %d-period low (%s). This is a %s signal (at %s)!",
+      low, o.exitLen, o.exit.Lower(), aurora.Bold(aurora.Red("SELL")), newCandle.CloseAmt(),
+    )
+
+    signal = o.emitSignal(broker.DowntrendDetected)
+  }
+
+  //
+  // Add the candle to the channels and the average true range.
+  //
+  o.entry.Add(high, low)
+  o.exit.Add(high, low)
+  o.atr.Add(high, low, newCandle.CloseAmt())
+
+  return signal
+}
+
+//
+// emitSignal returns the specified signal so that it can be routed to the Broker Service, caching
+// it in case we want to refer back to it at any point (e.g. in tests or user interfaces).
+//
+func (o *Algo) emitSignal(signal broker.Signal) broker.Signal {
+  o.lastSignal = signal
+
+  return signal
+}
@@ -0,0 +1,110 @@
+package donchian
+
+import (
+  "github.com/lukehollenback/goose/trader/broker"
+  "github.com/lukehollenback/goose/trader/candle"
+  "github.com/shopspring/decimal"
+  "testing"
+  "time"
+)
+
+const (
+  OneDay = 24 * time.Hour
+)
+
+var (
+  now = time.Now()
+)
+
+//
+// newCandle creates a daily candle with the provided high, low, and close.
+//
+func newCandle(high int64, low int64, close int64) *candle.Candle {
+  // NOTE ~> We do not care about the timestamp each of these candles are tagged too. We are not
+  //  testing candle stores here.
+
+  return candle.CreateFullCandle(
+    now, OneDay, decimal.NewFromInt(close), decimal.NewFromInt(close), decimal.NewFromInt(high),
+    decimal.NewFromInt(low), decimal.NewFromInt(close), decimal.NewFromInt(1),
+  )
+}
+
+//
+// seedAlgo generates the exact number of candles required to fill the algorithm's channels. In
+// order to ensure a constant and known algorithm state, all candles range from 95 to 105 and close
+// at 100.
+//
+func seedAlgo(o *Algo) {
+  for i := 0; i < o.WarmUp()-1; i++ {
+    o.OnCandle(newCandle(105, 95, 100))
+  }
+}
+
+func TestDailyBreakoutAndBreakdown(t *testing.T) {
+  //
+  // Instantiate the algorithm.
+  //
+  o, err := New(1440, 3, 2, 2)
+  if err != nil {
+    t.Fatalf("Failed to instantiate the algorithm. (Error: %s)", err)
+  }
+
+  //
+  // Seed the algorithm and verify that it is in the expected constant state.
+  //
+  seedAlgo(o)
+
+  if o.lastSignal != broker.None {
+    t.Fatalf("Expected no signal while filling the channels, but a %d signal was fired.", o.lastSignal)
+  }
+
+  //
+  // Simulate a candle whose high breaks out above the 3-period high of 105, even though it closes
+  // below it.
+  //
+  o.OnCandle(newCandle(110, 100, 104))
+
+  if o.lastSignal != broker.UptrendDetected {
+    t.Errorf(
+      "Expected an \"Uptrend Detected\" (signal %d) signal to have been fired, but instead a %d signal was.",
+      broker.UptrendDetected, o.lastSignal,
+    )
+  }
+
+  //
+  // Simulate a candle that stays inside the 2-period low of 95, and then one whose low breaks down
+  // below it.
+  //
+  if signal := o.OnCandle(newCandle(106, 96, 100)); signal != broker.None {
+    t.Errorf("Expected no signal while staying inside the channels, but got signal %d.", signal)
+  }
+
+  o.OnCandle(newCandle(100, 90, 92))
+
+  if o.lastSignal != broker.DowntrendDetected {
+    t.Errorf(
+      "Expected an \"Downtrend Detected\" (signal %d) signal to have been fired, but instead a %d signal was.",
+      broker.DowntrendDetected, o.lastSignal,
+    )
+  }
+}
+
+func TestDailySizeHint(t *testing.T) {
+  o, err := New(1440, 3, 2, 2)
+  if err != nil {
+    t.Fatalf("Failed to instantiate the algorithm. (Error: %s)", err)
+  }
+
+  if hint := o.SizeHint(); !hint.ATR.IsZero() {
+    t.Errorf("Expected no hint before the average true range is ready, but got an ATR of %s.", hint.ATR)
+  }
+
+  //
+  // Every seeded candle has a true range of 10.
+  //
+  seedAlgo(o)
+
+  if hint := o.SizeHint(); !hint.ATR.Equal(decimal.NewFromInt(10)) {
+    t.Errorf("Expected a hinted ATR of 10 but got %s.", hint.ATR)
+  }
+}
@@ -0,0 +1,317 @@
+package macd
+
+import (
+  "flag"
+  "fmt"
+  "github.com/logrusorgru/aurora"
+  "github.com/lukehollenback/goose/constants"
+  "github.com/lukehollenback/goose/trader/broker"
+  "github.com/lukehollenback/goose/trader/candle"
+  "github.com/lukehollenback/goose/trader/indicators"
+  "github.com/lukehollenback/goose/trader/strategy"
+  "github.com/shopspring/decimal"
+  "log"
+  "time"
+)
+
+const (
+  Name         = "≪macd≫"
+  StrategyName = "macd"
+)
+
+var (
+  logger *log.Logger
+
+  cfgPrecision *int
+  cfgPeriod    *int
+  cfgFastLen   *int
+  cfgSlowLen   *int
+  cfgSignalLen *int
+  cfgConfs     *int
+)
+
+func init() {
+  //
+  // Initialize the logger.
+  //
+  logger = log.New(log.Writer(), fmt.Sprintf(constants.LogPrefixFmt, Name), log.Ldate|log.Ltime|log.Lmsgprefix)
+
+  //
+  // Register and parse configuration flags.
+  //
+  cfgPrecision = flag.Int(
+    "macd-precision",
+    8,
+    fmt.Sprintf(
+      "The number of decimals to which the moving averages of asset values should be rounded to by the %s "+
+          "algorithm.",
+      Name,
+    ),
+  )
+
+  cfgPeriod = flag.Int(
+    "macd-period",
+    5,
+    fmt.Sprintf(
+      "The period length (in minutes) that the %s algorithm should watch (e.g. 5, 60, or 1440).",
+      Name,
+    ),
+  )
+
+  cfgFastLen = flag.Int(
+    "macd-fast-length",
+    12,
+    fmt.Sprintf(
+      "The length (in periods) of the fast exponential moving average for use by the %s algorithm.",
+      Name,
+    ),
+  )
+
+  cfgSlowLen = flag.Int(
+    "macd-slow-length",
+    26,
+    fmt.Sprintf(
+      "The length (in periods) of the slow exponential moving average for use by the %s algorithm.",
+      Name,
+    ),
+  )
+
+  cfgSignalLen = flag.Int(
+    "macd-signal-length",
+    9,
+    fmt.Sprintf(
+      "The length (in periods) of the exponential moving average of the MACD line (i.e. the signal line) "+
+          "for use by the %s algorithm.",
+      Name,
+    ),
+  )
+
+  cfgConfs = flag.Int(
+    "macd-confirmations",
+    1,
+    fmt.Sprintf(
+      "The number of consecutive periods that the histogram of the %s algorithm must stay on the same side "+
+          "of zero before a signal is emitted. A value of 1 signals as soon as the MACD line crosses the "+
+          "signal line.",
+      Name,
+    ),
+  )
+
+  //
+  // Make the algorithm available to be run.
+  //
+  strategy.Register(StrategyName, func() (strategy.Strategy, error) {
+    return NewFromFlags()
+  })
+}
+
+//
+// Algo represents an instance of the MACD signal line crossover algorithm. It implements the
+// Strategy interface.
+//
+type Algo struct {
+  period time.Duration // The interval of candles that the algorithm watches.
+
+  candleCnt int // The number of candles that have been provided to the algorithm.
+
+  fastLen       int              // Length of the fast exponential moving average.
+  slowLen       int              // Length of the slow exponential moving average.
+  signalLen     int              // Length of the exponential moving average of the MACD line.
+  lastSignal    broker.Signal    // The last signal that was fired by the algorithm.
+  macd          *indicators.MACD // The moving average convergence/divergence of candle closes.
+  histogram     decimal.Decimal  // Most-recently-calculated histogram (the MACD line minus the signal line).
+  histogramPrev decimal.Decimal  // Previously-calculated histogram.
+  histogramCnt  int              // The number of histograms that have been calculated.
+
+  uptrendConfs   int // Number of consecutive periods that the histogram has been above zero since crossing over.
+  downtrendConfs int // Number of consecutive periods that the histogram has been below zero since crossing over.
+  confsNeeded    int // Number of consecutive periods that are needed to emit a signal.
+}
+
+//
+// New instantiates a new, independent instance of the algorithm. The period is specified in minutes
+// and may be any whole number of them.
+//
+func New(period int, fastLen int, slowLen int, signalLen int, confs int) (*Algo, error) {
+  //
+  // Make sure that the configuration makes sense.
+  //
+  if err := candle.ValidateInterval(time.Duration(period) * time.Minute); err != nil {
+    return nil, fmt.Errorf("invalid period of %d minutes (%s)", period, err)
+  }
+
+  if confs <= 0 {
+    return nil, fmt.Errorf("the number of confirmations (%d) must be positive", confs)
+  }
+
+  //
+  // NOTE ~> Each of the moving averages is an EMA that is primed with the SMA of its first full
+  //  period, just like those of the moving averages algorithm.
+  //
+  macd, err := indicators.NewMACD(fastLen, slowLen, signalLen, int32(*cfgPrecision))
+  if err != nil {
+    return nil, err
+  }
+
+  //
+  // Instantiate the algorithm.
+  //
+  o := &Algo{
+    period: time.Duration(period) * time.Minute,
+
+    fastLen:       fastLen,
+    slowLen:       slowLen,
+    signalLen:     signalLen,
+    lastSignal:    broker.None,
+    macd:          macd,
+    histogram:     decimal.Zero,
+    histogramPrev: decimal.Zero,
+
+    confsNeeded: confs,
+  }
+
+  //
+  // Log some debug info.
+  //
+  logger.Printf(
+    "Initialized. (Period = %d minutes, Fast EMA = %d periods, Slow EMA = %d periods, Signal EMA = %d "+
+        "periods, Confirmations = %d).",
+    period, o.fastLen, o.slowLen, o.signalLen, o.confsNeeded,
+  )
+
+  return o, nil
+}
+
+//
+// NewFromFlags instantiates a new, independent instance of the algorithm as configured by the
+// command line flags.
+//
+func NewFromFlags() (*Algo, error) {
+  return New(*cfgPeriod, *cfgFastLen, *cfgSlowLen, *cfgSignalLen, *cfgConfs)
+}
+
+//
+// Name implements the Strategy interface's described method.
+//
+func (o *Algo) Name() string {
+  return StrategyName
+}
+
+//
+// Intervals implements the Strategy interface's described method.
+//
+func (o *Algo) Intervals() []time.Duration {
+  return []time.Duration{o.period}
+}
+
+//
+// WarmUp implements the Strategy interface's described method. The signal line can only start
+// averaging the MACD line once the slow moving average is ready. One more period is then needed so
+// that a previous histogram exists to detect crossovers against, and the histogram must then be
+// confirmed for the required number of periods.
+//
+func (o *Algo) WarmUp() int {
+  return o.slowLen + o.signalLen + o.confsNeeded - 1
+}
+
+//
+// OnCandle implements the Strategy interface's described method. It adds the newly-closed candle
+// that is provided to it to the algorithm's MACD and returns any signal that a confirmed crossover
+// of the MACD and signal lines triggers.
+//
+func (o *Algo) OnCandle(newCandle *candle.Candle) broker.Signal {
+  //
+  // Update the MACD with the newly-closed candle.
+  //
+  o.candleCnt++
+
+  o.macd.Add(newCandle.CloseAmt())
+
+  if !o.macd.Ready() {
+    logger.Printf(
+      "Not warmed up yet (%d/%d data points collected). The signal line has not yet been calculated.",
+      o.candleCnt,
+      o.slowLen+o.signalLen-1,
+    )
+
+    return broker.None
+  }
+
+  o.histogramPrev = o.histogram
+  o.histogram = o.macd.Histogram()
+  o.histogramCnt++
+
+  //
+  // If this is the first histogram that we have calculated, there is nothing to detect a crossover
+  // against yet.
+  //
+  // NOTE ~> Without this, warming up in the middle of an existing trend would look like a
+  //  crossover.
+  //
+  if o.histogramCnt < 2 {
+    logger.Printf(
+      "Not warmed up yet (%d/%d data points collected). The histogram has not yet been calculated twice.",
+      o.candleCnt,
+      o.slowLen+o.signalLen,
+    )
+
+    return broker.None
+  }
+
+  //
+  // Count how many consecutive periods the histogram has been on its current side of zero since it
+  // crossed over to it. The MACD line crossing above the signal line indicates a "buy" opportunity,
+  // and vice-versa for a "sell" opportunity. A flat histogram confirms neither.
+  //
+  switch {
+  case o.histogram.GreaterThan(decimal.Zero):
+    o.downtrendConfs = 0
+
+    if !o.histogramPrev.GreaterThan(decimal.Zero) {
+      o.uptrendConfs = 1
+    } else if o.uptrendConfs > 0 {
+      o.uptrendConfs++
+    }
+  case o.histogram.LessThan(decimal.Zero):
+    o.uptrendConfs = 0
+
+    if !o.histogramPrev.LessThan(decimal.Zero) {
+      o.downtrendConfs = 1
+    } else if o.downtrendConfs > 0 {
+      o.downtrendConfs++
+    }
+  default:
+    o.uptrendConfs = 0
+    o.downtrendConfs = 0
+  }
+
+  if o.uptrendConfs >= o.confsNeeded && o.lastSignal != broker.UptrendDetected {
+    logger.Printf(
+      "MACD (%s) has crossed ABOVE signal (%s) w/%d confirmations. This is a %s signal (at %s)!",
+      o.macd.Line(), o.macd.Signal(), o.uptrendConfs, aurora.Bold(aurora.Green("BUY")), newCandle.CloseAmt(),
+    )
+
+    return o.emitSignal(broker.UptrendDetected)
+  }
+
+  if o.downtrendConfs >= o.confsNeeded && o.lastSignal != broker.DowntrendDetected {
+    logger.Printf(
+      "MACD (%s) has crossed BELOW signal (%s) w/%d confirmations. This is a %s signal (at %s)!",
+      o.macd.Line(), o.macd.Signal(), o.downtrendConfs, aurora.Bold(aurora.Red("SELL")), newCandle.CloseAmt(),
+    )
+
+    return o.emitSignal(broker.DowntrendDetected)
+  }
+
+  return broker.None
+}
+
+//
+// emitSignal returns the specified signal so that it can be routed to the Broker Service, caching
+// it in case we want to refer back to it at any point (e.g. in tests or user interfaces).
+//
+func (o *Algo) emitSignal(signal broker.Signal) broker.Signal {
+  o.lastSignal = signal
+
+  return signal
+}
@@ -0,0 +1,138 @@
+package macd
+
+import (
+  "github.com/lukehollenback/goose/trader/broker"
+  "github.com/lukehollenback/goose/trader/candle"
+  "github.com/shopspring/decimal"
+  "testing"
+  "time"
+)
+
+const (
+  FiveMinutes = 5 * time.Minute
+)
+
+var (
+  now = time.Now()
+)
+
+//
+// seedAlgo generates the exact number of candles required to get the algorithm warmed up. In order
+// to ensure a constant and known algorithm state, all candles are given the exact same close value
+// of 100, which leaves the histogram flat.
+//
+func seedAlgo(o *Algo) {
+  for i := 0; i < o.slowLen+o.signalLen-1; i++ {
+    // NOTE ~> We do not care about the timestamp each of these candles are tagged too. We are not
+    //  testing candle stores here.
+
+    data := candle.CreateCandle(now, FiveMinutes, decimal.NewFromInt(100))
+
+    o.OnCandle(data)
+  }
+}
+
+func TestFiveMinuteMACDCrossesAboveSignal(t *testing.T) {
+  //
+  // Instantiate the algorithm.
+  //
+  o, err := New(5, 2, 3, 2, 1)
+  if err != nil {
+    t.Fatalf("Failed to instantiate the algorithm. (Error: %s)", err)
+  }
+
+  //
+  // Seed the algorithm and verify that it is in the expected constant state.
+  //
+  seedAlgo(o)
+
+  if !o.histogram.IsZero() || o.lastSignal != broker.None {
+    t.Fatalf("Expected a flat histogram and no signal after a flat market but the histogram was %s.", o.histogram)
+  }
+
+  //
+  // Simulate a jump. The fast EMA moves to ~106.67 and the slow EMA to 105, for a MACD line of
+  // ~1.67 and a signal line of ~1.11.
+  //
+  o.OnCandle(candle.CreateCandle(now, FiveMinutes, decimal.NewFromInt(110)))
+
+  if !o.histogram.Equal(decimal.RequireFromString("0.55555556")) {
+    t.Errorf("Expected the histogram to be 0.55555556 but was instead %s.", o.histogram)
+  }
+
+  if o.lastSignal != broker.UptrendDetected {
+    t.Errorf(
+      "Expected an \"Uptrend Detected\" (signal %d) signal to have been fired, but instead a %d signal was.",
+      broker.UptrendDetected, o.lastSignal,
+    )
+  }
+
+  //
+  // Simulate a drop, which pulls the MACD line well below the signal line.
+  //
+  o.OnCandle(candle.CreateCandle(now, FiveMinutes, decimal.NewFromInt(90)))
+
+  if o.lastSignal != broker.DowntrendDetected {
+    t.Errorf(
+      "Expected an \"Downtrend Detected\" (signal %d) signal to have been fired, but instead a %d signal was.",
+      broker.DowntrendDetected, o.lastSignal,
+    )
+  }
+}
+
+func TestFiveMinuteMACDWaitsForConfirmations(t *testing.T) {
+  //
+  // Instantiate the algorithm.
+  //
+  o, err := New(5, 2, 3, 2, 2)
+  if err != nil {
+    t.Fatalf("Failed to instantiate the algorithm. (Error: %s)", err)
+  }
+
+  seedAlgo(o)
+
+  //
+  // The first period above the signal line is not enough on its own.
+  //
+  if signal := o.OnCandle(candle.CreateCandle(now, FiveMinutes, decimal.NewFromInt(110))); signal != broker.None {
+    t.Errorf("Expected no signal after a single confirmation, but got signal %d.", signal)
+  }
+
+  //
+  // The second period still has the MACD line above the signal line (if only just), which confirms
+  // the crossover.
+  //
+  if signal := o.OnCandle(candle.CreateCandle(now, FiveMinutes, decimal.NewFromInt(110))); signal != broker.UptrendDetected {
+    t.Errorf(
+      "Expected an \"Uptrend Detected\" (signal %d) signal to have been fired after two confirmations, but "+
+          "instead a %d signal was.",
+      broker.UptrendDetected, signal,
+    )
+  }
+}
+
+func TestFiveMinuteMACDIgnoresTrendThatPredatesWarmUp(t *testing.T) {
+  //
+  // Instantiate the algorithm.
+  //
+  o, err := New(5, 2, 3, 2, 1)
+  if err != nil {
+    t.Fatalf("Failed to instantiate the algorithm. (Error: %s)", err)
+  }
+
+  //
+  // Seed the algorithm with an accelerating rise, so that the MACD line is already above the signal
+  // line by the time that the histogram is first calculated (and stays above it after that).
+  //
+  for i, v := range []int64{100, 101, 103, 106, 110, 115, 121} {
+    signal := o.OnCandle(candle.CreateCandle(now, FiveMinutes, decimal.NewFromInt(v)))
+
+    if i >= o.slowLen+o.signalLen-2 && !o.histogram.GreaterThan(decimal.Zero) {
+      t.Fatalf("Expected the histogram to be above zero throughout the rise but it was %s.", o.histogram)
+    }
+
+    if signal != broker.None {
+      t.Errorf("Expected no signal without an observed crossover, but got signal %d at candle %d.", signal, i+1)
+    }
+  }
+}
@@ -5,22 +5,21 @@ import (
   "fmt"
   "github.com/logrusorgru/aurora"
   "github.com/lukehollenback/goose/constants"
-  "github.com/lukehollenback/goose/structs/evictingqueue"
   "github.com/lukehollenback/goose/trader/broker"
   "github.com/lukehollenback/goose/trader/candle"
-  "github.com/lukehollenback/goose/trader/monitor"
+  "github.com/lukehollenback/goose/trader/indicators"
+  "github.com/lukehollenback/goose/trader/strategy"
   "github.com/shopspring/decimal"
   "log"
-  "sync"
+  "time"
 )
 
 const (
-  Name = "≪moving-averages≫"
+  Name         = "≪moving-averages≫"
+  StrategyName = "movingaverages"
 )
 
 var (
-  o      *Algo
-  once   sync.Once
   logger *log.Logger
 
   cfgPrecision *int
@@ -52,7 +51,7 @@ func init() {
     "ma-period",
     5,
     fmt.Sprintf(
-      "The period length (in minutes) that the %s algorithm should watch. Valid values are 1, 5, and 15.",
+      "The period length (in minutes) that the %s algorithm should watch (e.g. 5, 60, or 1440).",
       Name,
     ),
   )
@@ -83,213 +82,169 @@ func init() {
       Name,
     ),
   )
-}
-
-type Algo struct {
-  candles *evictingqueue.EvictingQueue // Holds references to the most recent one-minute candles that have been provided to the algorithm.
-
-  precision int32 // The number of decimals of precision that the asset's prices should be rounded to.
-
-  shortLen    decimal.Decimal // Length of the short-duration moving average.
-  longLen     decimal.Decimal // Length of the long-duration moving average.
-  lastSignal  broker.Signal   // The last signal that was fired by the algorithm.
-  maShort     decimal.Decimal // Most-recently-calculated short-duration moving average (either SMA or EMA depending on configuration).
-  maShortPrev decimal.Decimal // Previously-calculated short-duration moving average (either SMA or EMA depending on configuration).
-  maLong      decimal.Decimal // Most-recently-calculated long-duration moving average (either SMA or EMA depending on configuration).
-  maLongPrev  decimal.Decimal // Previously-calculated long-duration moving average (either SMA or EMA depending on configuration).
-
-  smaShort     decimal.Decimal // Most-recently-calculated short-duration moving average.
-  smaShortPrev decimal.Decimal // Previously-calculated short-duration moving average.
-  smaLong      decimal.Decimal // Most-recently-calculated long-duration moving average.
-  smaLongPrev  decimal.Decimal // Previously-calculated long-duration moving average.
-
-  emaEnabled   bool            // Whether or not to use the exponential moving average instead of the simple moving average.
-  emaShort     decimal.Decimal // Most-recently-calculated short-duration exponential moving average.
-  emaShortPrev decimal.Decimal // Previously-calculated short-duration exponential moving average.
-  emaLong      decimal.Decimal // Most-recently-calculated long-duration exponential moving average.
-  emaLongPrev  decimal.Decimal // Previously-calculated long-duration exponential moving average.
-
-  uptrendConfs       int // Number of uptrend confirmations that have occurred.
-  uptrendConfsNeeded int // Number of uptrend confirmations that are needed to emit an uptrend signal.
-}
-
-//
-// Init initializes the algorithm and registers its signal handlers with the Trade Monitor Service.
-// Allows for the specification of initialization flags via parameters. Trade algorithms can only be
-// initialized once – subsequent calls will simply return their singleton instance.
-//
-func InitWithFlags(period int, longLen int, shortLen int, exp bool) *Algo {
-  once.Do(func() {
-    //
-    // Instantiate the algorithm.
-    //
-    o = &Algo{
-      candles: evictingqueue.New(longLen + 1),
-
-      precision: int32(*cfgPrecision),
-
-      shortLen:    decimal.NewFromInt(int64(shortLen)),
-      longLen:     decimal.NewFromInt(int64(longLen)),
-      lastSignal:  broker.None,
-      maShort:     constants.NegOne(),
-      maShortPrev: constants.NegOne(),
-      maLong:      constants.NegOne(),
-      maLongPrev:  constants.NegOne(),
-
-      smaShort:     constants.NegOne(),
-      smaShortPrev: constants.NegOne(),
-      smaLong:      constants.NegOne(),
-      smaLongPrev:  constants.NegOne(),
-
-      emaEnabled:   exp,
-      emaShort:     constants.NegOne(),
-      emaShortPrev: constants.NegOne(),
-      emaLong:      constants.NegOne(),
-      emaLongPrev:  constants.NegOne(),
-
-      uptrendConfs: 0,
-      uptrendConfsNeeded: 0,
-    }
-
-    //
-    // Register the correct candle close listener for the configured period length.
-    //
-    if period == 1 {
-      monitor.Instance().RegisterOneMinCandleCloseHandler(o.candleCloseHandler)
-    } else if period == 5 {
-      monitor.Instance().RegisterFiveMinCandleCloseHandler(o.candleCloseHandler)
-    } else if period == 15 {
-      monitor.Instance().RegisterFifteenMinCandleCloseHandler(o.candleCloseHandler)
-    } else {
-      // TODO ~> Throw an error.
-    }
 
-    //
-    // Log some debug info.
-    //
-    logger.Printf(
-      "Initialized. (Period = %d minutes, Long MA = %s periods, Short MA = %s periods, Exponential = %t).",
-      *cfgPeriod, o.longLen, o.shortLen, o.emaEnabled,
-    )
+  //
+  // Make the algorithm available to be run.
+  //
+  strategy.Register(StrategyName, func() (strategy.Strategy, error) {
+    return NewFromFlags()
   })
-
-  return o
 }
 
 //
-// Init initializes the algorithm and registers its signal handlers with the Trade Monitor Service.
-// Trade algorithms can only be initialized once – subsequent calls will simply return their
-// singleton instance.
+// Algo represents an instance of the moving averages crossover algorithm. It implements the
+// Strategy interface.
 //
-func Init() *Algo {
-  return InitWithFlags(*cfgPeriod, *cfgLongLen, *cfgShortLen, *cfgExp)
+type Algo struct {
+  period time.Duration // The interval of candles that the algorithm watches.
+
+  candleCnt int // The number of candles that have been provided to the algorithm.
+
+  shortLen    int               // Length of the short-duration moving average.
+  longLen     int               // Length of the long-duration moving average.
+  lastSignal  broker.Signal     // The last signal that was fired by the algorithm.
+  short       indicators.Series // The short-duration moving average (either SMA or EMA depending on configuration).
+  long        indicators.Series // The long-duration moving average (either SMA or EMA depending on configuration).
+  maShort     decimal.Decimal   // Most-recently-calculated short-duration moving average.
+  maShortPrev decimal.Decimal   // Previously-calculated short-duration moving average.
+  maLong      decimal.Decimal   // Most-recently-calculated long-duration moving average.
+  maLongPrev  decimal.Decimal   // Previously-calculated long-duration moving average.
+  emaEnabled  bool              // Whether or not to use the exponential moving average instead of the simple moving average.
 }
 
 //
-// DeInit disables the algorithm so that it can be re-initialized again in the future if necessary.
-// This should really only be used to support tests.
+// New instantiates a new, independent instance of the algorithm. The period is specified in minutes
+// and may be any whole number of them.
 //
-func DeInit() {
+func New(period int, longLen int, shortLen int, exp bool) (*Algo, error) {
   //
-  // Reset the singleton lock.
+  // Make sure that the configuration makes sense.
   //
-  //goland:noinspection GoVetCopyLock
-  once = *(new(sync.Once))
+  if err := candle.ValidateInterval(time.Duration(period) * time.Minute); err != nil {
+    return nil, fmt.Errorf("invalid period of %d minutes (%s)", period, err)
+  }
 
-  //
-  // Unregister candle close handlers
-  //
-  // TODO ~> This.
-  //
-}
+  if shortLen <= 0 || longLen <= shortLen {
+    return nil, fmt.Errorf("the short length (%d) must be positive and less than the long length (%d)", shortLen, longLen)
+  }
 
-//
-// candleCloseHandler is this algorithm's "candle close handler". It adds the newly-closed candle
-// that is provided to it to the algorithm's data structure, updates calculated moving averages
-// based on historically-handled candles.
-//
-func (o *Algo) candleCloseHandler(newCandle *candle.Candle) {
   //
-  // Add the new candle to the evicting queue of candles known by the algorithm's instance. If the
-  // evicting queue is already full, the oldest entry will be evicted.
+  // Instantiate the moving averages.
   //
-  o.candles.Add(newCandle)
+  short, err := newMovingAverage(shortLen, exp)
+  if err != nil {
+    return nil, err
+  }
+
+  long, err := newMovingAverage(longLen, exp)
+  if err != nil {
+    return nil, err
+  }
 
   //
-  // We are going to need an int64 representation of the current candles queue.
+  // Instantiate the algorithm.
   //
-  candlesCurLen := int64(o.candles.Len())
+  o := &Algo{
+    period: time.Duration(period) * time.Minute,
+
+    shortLen:    shortLen,
+    longLen:     longLen,
+    lastSignal:  broker.None,
+    short:       short,
+    long:        long,
+    maShort:     constants.NegOne(),
+    maShortPrev: constants.NegOne(),
+    maLong:      constants.NegOne(),
+    maLongPrev:  constants.NegOne(),
+    emaEnabled:  exp,
+  }
 
   //
-  // Calculate the short moving averages.
+  // Log some debug info.
   //
-  if candlesCurLen >= o.shortLen.IntPart() {
-    //
-    // Calculate the short SMA.
-    //
-    o.smaShortPrev = o.smaShort
-    o.smaShort = o.calculateSimpleMovingAverage(o.shortLen)
+  logger.Printf(
+    "Initialized. (Period = %d minutes, Long MA = %d periods, Short MA = %d periods, Exponential = %t).",
+    period, o.longLen, o.shortLen, o.emaEnabled,
+  )
 
-    //
-    // Calculate the short EMA. To accurately do this, we must wait one extra period after the
-    // calculation of the SMA.
-    //
-    // NOTE ~> If this is the first EMA that we are calculating, we must prime it with the most
-    //  recently-calculated SMA.
-    //
-    if candlesCurLen > o.shortLen.IntPart() {
-      if o.emaShort == constants.NegOne() {
-        o.emaShortPrev = o.smaShortPrev
-      } else {
-        o.emaShortPrev = o.emaShort
-      }
+  return o, nil
+}
 
-      o.emaShort = o.calculateExponentialMovingAverage(newCandle.CloseAmt(), o.emaShortPrev, o.shortLen)
-    }
+//
+// newMovingAverage instantiates either a simple or an exponential moving average of the specified
+// length, rounded to the configured precision.
+//
+// NOTE ~> An exponential moving average is seeded with the simple moving average of its first full
+//  period, so it takes one extra period before a previous value exists to detect cross-overs
+//  against – just like a simple moving average.
+//
+func newMovingAverage(length int, exp bool) (indicators.Series, error) {
+  if exp {
+    return indicators.NewEMA(length, int32(*cfgPrecision))
   }
 
+  return indicators.NewSMA(length, int32(*cfgPrecision))
+}
+
+//
+// NewFromFlags instantiates a new, independent instance of the algorithm as configured by the
+// command line flags.
+//
+func NewFromFlags() (*Algo, error) {
+  return New(*cfgPeriod, *cfgLongLen, *cfgShortLen, *cfgExp)
+}
+
+//
+// Name implements the Strategy interface's described method.
+//
+func (o *Algo) Name() string {
+  return StrategyName
+}
+
+//
+// Intervals implements the Strategy interface's described method.
+//
+func (o *Algo) Intervals() []time.Duration {
+  return []time.Duration{o.period}
+}
+
+//
+// WarmUp implements the Strategy interface's described method. The long moving average needs one
+// extra period beyond its length so that a previous value exists to detect cross-overs against.
+//
+func (o *Algo) WarmUp() int {
+  return o.longLen + 1
+}
+
+//
+// OnCandle implements the Strategy interface's described method. It adds the newly-closed candle
+// that is provided to it to the algorithm's moving averages and returns any signal that they
+// trigger.
+//
+func (o *Algo) OnCandle(newCandle *candle.Candle) broker.Signal {
   //
-  // Calculate the long moving averages.
+  // Update the moving averages with the newly-closed candle.
   //
-  if candlesCurLen >= o.longLen.IntPart() {
-    //
-    // Calculate the short SMA.
-    //
-    o.smaLongPrev = o.smaLong
-    o.smaLong = o.calculateSimpleMovingAverage(o.longLen)
+  o.candleCnt++
 
-    //
-    // Calculate the long EMA. To accurately do this, we must wait one extra period after the
-    // calculation of the SMA.
-    //
-    // NOTE ~> If this is the first EMA that we are calculating, we must prime it with the most
-    //  recently-calculated SMA.
-    //
-    if candlesCurLen > o.longLen.IntPart() {
-      if o.emaLong == constants.NegOne() {
-        o.emaLongPrev = o.smaLongPrev
-      } else {
-        o.emaLongPrev = o.emaLong
-      }
-
-      o.emaLong = o.calculateExponentialMovingAverage(newCandle.CloseAmt(), o.emaLongPrev, o.longLen)
-    }
-  }
+  o.short.Add(newCandle.CloseAmt())
+  o.long.Add(newCandle.CloseAmt())
 
   //
   // Determine if a signal should be fired given the above-calculated moving averages. If we do not
   // yet have a calculation for both moving averages, we must skip this step as the algorithm is not
   // warmed up enough.
   //
-  o.maShort, o.maShortPrev, o.maLong, o.maLongPrev = o.getMovingAverages()
+  o.maShortPrev, o.maShort = o.maShort, currentValue(o.short)
+  o.maLongPrev, o.maLong = o.maLong, currentValue(o.long)
 
   if o.maShort.Equal(constants.NegOne()) || o.maShortPrev.Equal(constants.NegOne()) ||
       o.maLong.Equal(constants.NegOne()) || o.maLongPrev.Equal(constants.NegOne()) {
     logger.Printf(
-      "Not warmed up yet (%d/%s data points collected). One or all moving averages has"+
+      "Not warmed up yet (%d/%d data points collected). One or all moving averages has"+
           " not yet been calculated.",
-      o.candles.Len(),
-      o.longLen.Add(constants.One()),
+      o.candleCnt,
+      o.longLen+1,
     )
   } else {
     //
@@ -302,104 +257,47 @@ func (o *Algo) candleCloseHandler(newCandle *candle.Candle) {
     shortBelowLong := o.maShort.LessThan(o.maLong)
 
     if shortAboveLong {
-      o.uptrendConfs++
-
-      if o.uptrendConfs >= o.uptrendConfsNeeded && o.lastSignal != broker.UptrendDetected {
+      if o.lastSignal != broker.UptrendDetected {
         logger.Printf(
-          "Short MA (%s) has crossed ABOVE long MA (%s) w/%d confirmations. This is a %s signal (at %s)!",
-          o.maShort, o.maLong, o.uptrendConfs, aurora.Bold(aurora.Green("BUY")), newCandle.CloseAmt(),
+          "Short MA (%s) has crossed ABOVE long MA (%s). This is a %s signal (at %s)!",
+          o.maShort, o.maLong, aurora.Bold(aurora.Green("BUY")), newCandle.CloseAmt(),
         )
 
-        o.emitSignal(broker.UptrendDetected, newCandle)
+        return o.emitSignal(broker.UptrendDetected)
       }
     } else if shortBelowLong {
-      o.uptrendConfs = 0
-
       if o.lastSignal != broker.DowntrendDetected {
         logger.Printf(
           "Short MA (%s) has crossed BELOW long MA (%s). This is a %s signal (at %s)!",
           o.maShort, o.maLong, aurora.Bold(aurora.Red("SELL")), newCandle.CloseAmt(),
         )
 
-        o.emitSignal(broker.DowntrendDetected, newCandle)
+        return o.emitSignal(broker.DowntrendDetected)
       }
     }
   }
-}
 
-//
-// emitSignal fires off the specified signal, as triggered by the specified candle, to the Broker
-// Service so that it can act upon it.
-//
-func (o *Algo) emitSignal(signal broker.Signal, candle *candle.Candle) {
-  //
-  // Actually emit the signal to the Broker Service.
-  //
-  broker.Instance().Signal(signal, candle.CloseAmt(), candle.End())
-
-  //
-  // Cache the just-emitted signal in case we want to refer back to it at any point (e.g. in tests
-  // or user interfaces).
-  //
-  o.lastSignal = signal
+  return broker.None
 }
 
 //
-// getMovingAverages returns the proper short and long moving average values depending on how the
-// algorithm is configured.
+// emitSignal returns the specified signal so that it can be routed to the Broker Service, caching
+// it in case we want to refer back to it at any point (e.g. in tests or user interfaces).
 //
-func (o *Algo) getMovingAverages() (maShort decimal.Decimal, maShortPrev decimal.Decimal,
-    maLong decimal.Decimal, maLongPrev decimal.Decimal) {
-  if o.emaEnabled {
-    return o.emaShort, o.emaShortPrev, o.emaLong, o.emaLongPrev
-  }
+func (o *Algo) emitSignal(signal broker.Signal) broker.Signal {
+  o.lastSignal = signal
 
-  return o.smaShort, o.smaShortPrev, o.smaLong, o.smaLongPrev
+  return signal
 }
 
 //
-// calculateSimpleMovingAverage calculates and returns a "simple moving average" (SMA) against
-// however many recently-handled candles are specified to be looked at.
+// currentValue returns the current value of the provided moving average, or negative one if it has
+// not yet been calculated.
 //
-func (o *Algo) calculateSimpleMovingAverage(lookback decimal.Decimal) decimal.Decimal {
-  first := o.candles.Len() - 1
-  last := int64(o.candles.Len()) - lookback.IntPart()
-  cur, _ := o.candles.Get(first)
-  avg := cur.(*candle.Candle).CloseAmt()
-
-  // NOTE ~> We add the first candle into the average before the loop, so we must skip it in the
-  //  loop so as not to double-weight its value.
-
-  for i := first - 1; int64(i) >= last; i-- {
-    cur, _ := o.candles.Get(i)
-
-    avg = avg.Add(cur.(*candle.Candle).CloseAmt())
+func currentValue(ma indicators.Series) decimal.Decimal {
+  if !ma.Ready() {
+    return constants.NegOne()
   }
 
-  return avg.Div(lookback).Round(o.precision)
-}
-
-//
-// calculateExponentialMovingAverage calculates and returns an "exponential moving average" (EMA)
-// data point for the provided just-closed period.
-//
-func (o *Algo) calculateExponentialMovingAverage(
-    curCloseAmt decimal.Decimal,
-    prevEMA decimal.Decimal,
-    periods decimal.Decimal,
-) decimal.Decimal {
-  // NOTE ~> EMA = (closing price - previous day's EMA) × smoothing constant as a decimal
-  //  + previous day's EMA
-
-  //
-  // Calculate the exponential smoothing factor.
-  //
-  // NOTE ~> EMA Smoothing Factor = 2 ÷ (number of time periods + 1)
-  //
-  factor := constants.Two().Div(periods.Add(constants.One()))
-
-  //
-  // Calculate and return the EMA.
-  //
-  return curCloseAmt.Sub(prevEMA).Mul(factor).Add(prevEMA).Round(o.precision)
+  return ma.Value()
 }
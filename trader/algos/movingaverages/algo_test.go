@@ -21,31 +21,30 @@ var (
 // to ensure a constant and known algorithm state, all candles are given the exact same close value
 // of 5000.
 //
-func seedAlgo() {
+func seedAlgo(o *Algo) {
   for i := 0; i < 15; i++ {
     // NOTE ~> We do not care about the timestamp each of these candles are tagged too. We are not
     //  testing candle stores here.
 
     data := candle.CreateCandle(now, FiveMinutes, decimal.NewFromInt(10000))
 
-    o.candleCloseHandler(data)
+    o.OnCandle(data)
   }
 }
 
 func TestFiveMinuteSMAFiveOverFifteen(t *testing.T) {
   //
-  // Initialize the algorithm.
+  // Instantiate the algorithm.
   //
-  // NOTE ~> We must first forcefully reset the singleton lock in case a previous test already tried
-  //  to initialize the algorithm.
-  //
-  DeInit()
-  Init()
+  o, err := NewFromFlags()
+  if err != nil {
+    t.Fatalf("Failed to instantiate the algorithm. (Error: %s)", err)
+  }
 
   //
   // Seed the algorithm and verify that it is in the expected constant state.
   //
-  seedAlgo()
+  seedAlgo(o)
 
   //
   // Simulate a short-over-long crossover and validate that averages were calculated properly and
@@ -53,7 +52,7 @@ func TestFiveMinuteSMAFiveOverFifteen(t *testing.T) {
   //
   data := candle.CreateCandle(now, FiveMinutes, decimal.NewFromInt(19000))
 
-  o.candleCloseHandler(data)
+  o.OnCandle(data)
 
   if !o.maShort.Equal(decimal.NewFromInt(11800)) {
     t.Errorf("Expected SMA Short to be 11,800 but was instead %s.", o.maShort)
@@ -73,18 +72,17 @@ func TestFiveMinuteSMAFiveOverFifteen(t *testing.T) {
 
 func TestFiveMinuteSMAFiveUnderFifteen(t *testing.T) {
   //
-  // Initialize the algorithm.
+  // Instantiate the algorithm.
   //
-  // NOTE ~> We must first forcefully reset the singleton lock in case a previous test already tried
-  //  to initialize the algorithm.
-  //
-  DeInit()
-  Init()
+  o, err := NewFromFlags()
+  if err != nil {
+    t.Fatalf("Failed to instantiate the algorithm. (Error: %s)", err)
+  }
 
   //
   // Seed the algorithm and verify that it is in the expected constant state.
   //
-  seedAlgo()
+  seedAlgo(o)
 
   //
   // Simulate a short-under-long crossover and validate that averages were calculated properly and
@@ -92,7 +90,7 @@ func TestFiveMinuteSMAFiveUnderFifteen(t *testing.T) {
   //
   data := candle.CreateCandle(now, FiveMinutes, decimal.NewFromInt(1000))
 
-  o.candleCloseHandler(data)
+  o.OnCandle(data)
 
   if !o.maShort.Equal(decimal.NewFromInt(8200)) {
     t.Errorf("Expected SMA Short to be 8,200 but was instead %s.", o.maShort)
@@ -112,18 +110,17 @@ func TestFiveMinuteSMAFiveUnderFifteen(t *testing.T) {
 
 func TestFiveMinuteEMAFiveOverFifteen(t *testing.T) {
   //
-  // Initialize the algorithm.
+  // Instantiate the algorithm.
   //
-  // NOTE ~> We must first forcefully reset the singleton lock in case a previous test already tried
-  //  to initialize the algorithm.
-  //
-  DeInit()
-  InitWithFlags(5, 15, 5, true)
+  o, err := New(5, 15, 5, true)
+  if err != nil {
+    t.Fatalf("Failed to instantiate the algorithm. (Error: %s)", err)
+  }
 
   //
   // Seed the algorithm and verify that it is in the expected constant state.
   //
-  seedAlgo()
+  seedAlgo(o)
 
   //
   // Simulate a short-over-long crossover and validate that averages were calculated properly and
@@ -131,7 +128,7 @@ func TestFiveMinuteEMAFiveOverFifteen(t *testing.T) {
   //
   data := candle.CreateCandle(now, FiveMinutes, decimal.NewFromInt(19000))
 
-  o.candleCloseHandler(data)
+  o.OnCandle(data)
 
   if !(o.maShort.GreaterThan(decimal.NewFromInt(12999)) && o.maShort.LessThan(decimal.NewFromInt(13000))) {
     t.Errorf("Expected EMA Short to be ~13,000 but was instead %s.", o.maShort)
@@ -151,18 +148,17 @@ func TestFiveMinuteEMAFiveOverFifteen(t *testing.T) {
 
 func TestFiveMinuteEMAFiveUnderFifteen(t *testing.T) {
   //
-  // Initialize the algorithm.
+  // Instantiate the algorithm.
   //
-  // NOTE ~> We must first forcefully reset the singleton lock in case a previous test already tried
-  //  to initialize the algorithm.
-  //
-  DeInit()
-  InitWithFlags(5, 15, 5, true)
+  o, err := New(5, 15, 5, true)
+  if err != nil {
+    t.Fatalf("Failed to instantiate the algorithm. (Error: %s)", err)
+  }
 
   //
   // Seed the algorithm and verify that it is in the expected constant state.
   //
-  seedAlgo()
+  seedAlgo(o)
 
   //
   // Simulate a short-over-long crossover and validate that averages were calculated properly and
@@ -170,7 +166,7 @@ func TestFiveMinuteEMAFiveUnderFifteen(t *testing.T) {
   //
   data := candle.CreateCandle(now, FiveMinutes, decimal.NewFromInt(1000))
 
-  o.candleCloseHandler(data)
+  o.OnCandle(data)
 
   if !(o.maShort.GreaterThan(decimal.NewFromInt(7000)) && o.maShort.LessThan(decimal.NewFromInt(7001))) {
     t.Errorf("Expected EMA Short to be ~7,000 but was instead %s.", o.maShort)
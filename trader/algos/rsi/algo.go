@@ -0,0 +1,268 @@
+package rsi
+
+import (
+  "flag"
+  "fmt"
+  "github.com/logrusorgru/aurora"
+  "github.com/lukehollenback/goose/constants"
+  "github.com/lukehollenback/goose/trader/broker"
+  "github.com/lukehollenback/goose/trader/candle"
+  "github.com/lukehollenback/goose/trader/indicators"
+  "github.com/lukehollenback/goose/trader/strategy"
+  "github.com/shopspring/decimal"
+  "log"
+  "time"
+)
+
+const (
+  Name         = "≪rsi≫"
+  StrategyName = "rsi"
+)
+
+var (
+  logger *log.Logger
+
+  cfgPrecision  *int
+  cfgPeriod     *int
+  cfgLength     *int
+  cfgOversold   *float64
+  cfgOverbought *float64
+)
+
+func init() {
+  //
+  // Initialize the logger.
+  //
+  logger = log.New(log.Writer(), fmt.Sprintf(constants.LogPrefixFmt, Name), log.Ldate|log.Ltime|log.Lmsgprefix)
+
+  //
+  // Register and parse configuration flags.
+  //
+  cfgPrecision = flag.Int(
+    "rsi-precision",
+    8,
+    fmt.Sprintf(
+      "The number of decimals to which the average gains and losses of asset values (and the resulting "+
+          "RSI) should be rounded to by the %s algorithm.",
+      Name,
+    ),
+  )
+
+  cfgPeriod = flag.Int(
+    "rsi-period",
+    5,
+    fmt.Sprintf(
+      "The period length (in minutes) that the %s algorithm should watch (e.g. 5, 60, or 1440).",
+      Name,
+    ),
+  )
+
+  cfgLength = flag.Int(
+    "rsi-length",
+    14,
+    fmt.Sprintf(
+      "The length (in periods) of the relative strength index for use by the %s algorithm.",
+      Name,
+    ),
+  )
+
+  cfgOversold = flag.Float64(
+    "rsi-oversold",
+    30,
+    fmt.Sprintf(
+      "The RSI below which the %s algorithm considers an asset to be oversold. Crossing back up through it "+
+          "is a buy signal.",
+      Name,
+    ),
+  )
+
+  cfgOverbought = flag.Float64(
+    "rsi-overbought",
+    70,
+    fmt.Sprintf(
+      "The RSI above which the %s algorithm considers an asset to be overbought. Crossing back down through "+
+          "it is a sell signal.",
+      Name,
+    ),
+  )
+
+  //
+  // Make the algorithm available to be run.
+  //
+  strategy.Register(StrategyName, func() (strategy.Strategy, error) {
+    return NewFromFlags()
+  })
+}
+
+//
+// Algo represents an instance of the RSI mean-reversion algorithm. It implements the Strategy
+// interface.
+//
+type Algo struct {
+  period time.Duration // The interval of candles that the algorithm watches.
+
+  candleCnt int // The number of candles that have been provided to the algorithm.
+
+  length     int             // Length of the relative strength index.
+  oversold   decimal.Decimal // The RSI below which an asset is considered oversold.
+  overbought decimal.Decimal // The RSI above which an asset is considered overbought.
+  lastSignal broker.Signal   // The last signal that was fired by the algorithm.
+  rsi        *indicators.RSI // The relative strength index of candle closes.
+  rsiCur     decimal.Decimal // Most-recently-calculated relative strength index.
+  rsiPrev    decimal.Decimal // Previously-calculated relative strength index.
+}
+
+//
+// New instantiates a new, independent instance of the algorithm. The period is specified in minutes
+// and may be any whole number of them. The thresholds are RSI values between 0 and 100.
+//
+func New(period int, length int, oversold float64, overbought float64) (*Algo, error) {
+  //
+  // Make sure that the configuration makes sense.
+  //
+  if err := candle.ValidateInterval(time.Duration(period) * time.Minute); err != nil {
+    return nil, fmt.Errorf("invalid period of %d minutes (%s)", period, err)
+  }
+
+  if oversold <= 0 || overbought <= oversold || overbought >= 100 {
+    return nil, fmt.Errorf(
+      "the oversold threshold (%g) must be positive and less than the overbought threshold (%g), which must "+
+          "be less than 100",
+      oversold, overbought,
+    )
+  }
+
+  rsi, err := indicators.NewRSI(length, int32(*cfgPrecision))
+  if err != nil {
+    return nil, err
+  }
+
+  //
+  // Instantiate the algorithm.
+  //
+  o := &Algo{
+    period: time.Duration(period) * time.Minute,
+
+    length:     length,
+    oversold:   decimal.NewFromFloat(oversold),
+    overbought: decimal.NewFromFloat(overbought),
+    lastSignal: broker.None,
+    rsi:        rsi,
+    rsiCur:     constants.NegOne(),
+    rsiPrev:    constants.NegOne(),
+  }
+
+  //
+  // Log some debug info.
+  //
+  logger.Printf(
+    "Initialized. (Period = %d minutes, RSI = %d periods, Oversold = %s, Overbought = %s).",
+    period, o.length, o.oversold, o.overbought,
+  )
+
+  return o, nil
+}
+
+//
+// NewFromFlags instantiates a new, independent instance of the algorithm as configured by the
+// command line flags.
+//
+func NewFromFlags() (*Algo, error) {
+  return New(*cfgPeriod, *cfgLength, *cfgOversold, *cfgOverbought)
+}
+
+//
+// Name implements the Strategy interface's described method.
+//
+func (o *Algo) Name() string {
+  return StrategyName
+}
+
+//
+// Intervals implements the Strategy interface's described method.
+//
+func (o *Algo) Intervals() []time.Duration {
+  return []time.Duration{o.period}
+}
+
+//
+// WarmUp implements the Strategy interface's described method. The relative strength index needs
+// one more candle than its length (as it is calculated from changes between closes), plus one more
+// so that a previous value exists to detect threshold crossings against.
+//
+func (o *Algo) WarmUp() int {
+  return o.length + 2
+}
+
+//
+// OnCandle implements the Strategy interface's described method. It adds the newly-closed candle
+// that is provided to it to the algorithm's relative strength index and returns any signal that a
+// threshold crossing triggers.
+//
+func (o *Algo) OnCandle(newCandle *candle.Candle) broker.Signal {
+  //
+  // Update the relative strength index with the newly-closed candle.
+  //
+  o.candleCnt++
+
+  o.rsi.Add(newCandle.CloseAmt())
+
+  o.rsiPrev = o.rsiCur
+  if o.rsi.Ready() {
+    o.rsiCur = o.rsi.Value()
+  }
+
+  //
+  // Determine if a signal should be fired. If we do not yet have both a current and a previous
+  // value, we must skip this step as the algorithm is not warmed up enough.
+  //
+  if o.rsiCur.Equal(constants.NegOne()) || o.rsiPrev.Equal(constants.NegOne()) {
+    logger.Printf(
+      "Not warmed up yet (%d/%d data points collected). The RSI has not yet been calculated twice.",
+      o.candleCnt,
+      o.WarmUp(),
+    )
+
+    return broker.None
+  }
+
+  //
+  // Determine if the RSI has crossed back up out of oversold territory (indicating a "buy"
+  // opportunity), or back down out of overbought territory (indicating a "sell" opportunity).
+  //
+  // NOTE ~> Simply being oversold or overbought is not a signal, as an asset can stay that way for
+  //  a long time while it continues to trend. We wait for the move to start reverting.
+  //
+  crossedUp := o.rsiPrev.LessThan(o.oversold) && !o.rsiCur.LessThan(o.oversold)
+  crossedDown := o.rsiPrev.GreaterThan(o.overbought) && !o.rsiCur.GreaterThan(o.overbought)
+
+  if crossedUp && o.lastSignal != broker.UptrendDetected {
+    logger.Printf(
+      "RSI (%s) has crossed back ABOVE the oversold threshold (%s). This is a %s signal (at %s)!",
+      o.rsiCur, o.oversold, aurora.Bold(aurora.Green("BUY")), newCandle.CloseAmt(),
+    )
+
+    return o.emitSignal(broker.UptrendDetected)
+  }
+
+  if crossedDown && o.lastSignal != broker.DowntrendDetected {
+    logger.Printf(
+      "RSI (%s) has crossed back BELOW the overbought threshold (%s). This is a %s signal (at %s)!",
+      o.rsiCur, o.overbought, aurora.Bold(aurora.Red("SELL")), newCandle.CloseAmt(),
+    )
+
+    return o.emitSignal(broker.DowntrendDetected)
+  }
+
+  return broker.None
+}
+
+//
+// emitSignal returns the specified signal so that it can be routed to the Broker Service, caching
+// it in case we want to refer back to it at any point (e.g. in tests or user interfaces).
+//
+func (o *Algo) emitSignal(signal broker.Signal) broker.Signal {
+  o.lastSignal = signal
+
+  return signal
+}
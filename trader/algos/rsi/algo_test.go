@@ -0,0 +1,110 @@
+package rsi
+
+import (
+  "github.com/lukehollenback/goose/trader/broker"
+  "github.com/lukehollenback/goose/trader/candle"
+  "github.com/shopspring/decimal"
+  "testing"
+  "time"
+)
+
+const (
+  FiveMinutes = 5 * time.Minute
+)
+
+var (
+  now = time.Now()
+)
+
+//
+// seedAlgo provides the algorithm with candles that close at each of the provided values, returning
+// the signal that the last one triggered.
+//
+func seedAlgo(o *Algo, closes ...int64) broker.Signal {
+  signal := broker.None
+
+  for _, v := range closes {
+    // NOTE ~> We do not care about the timestamp each of these candles are tagged too. We are not
+    //  testing candle stores here.
+
+    data := candle.CreateCandle(now, FiveMinutes, decimal.NewFromInt(v))
+
+    signal = o.OnCandle(data)
+  }
+
+  return signal
+}
+
+func TestFiveMinuteRSICrossesUpThroughOversold(t *testing.T) {
+  //
+  // Instantiate the algorithm.
+  //
+  o, err := New(5, 2, 30, 70)
+  if err != nil {
+    t.Fatalf("Failed to instantiate the algorithm. (Error: %s)", err)
+  }
+
+  //
+  // Seed the algorithm with two straight losses so that it is as oversold as it can be.
+  //
+  if signal := seedAlgo(o, 100, 90, 80); signal != broker.None || !o.rsiCur.IsZero() {
+    t.Fatalf("Expected an RSI of 0 and no signal after two straight losses, but got %s and signal %d.", o.rsiCur, signal)
+  }
+
+  //
+  // Simulate a rebound. Average gains and losses are smoothed to 10 and 5, for an RSI of ~66.67.
+  //
+  seedAlgo(o, 100)
+
+  if !(o.rsiCur.GreaterThan(decimal.NewFromInt(66)) && o.rsiCur.LessThan(decimal.NewFromInt(67))) {
+    t.Errorf("Expected the RSI to be ~66.67 but was instead %s.", o.rsiCur)
+  }
+
+  if o.lastSignal != broker.UptrendDetected {
+    t.Errorf(
+      "Expected an \"Uptrend Detected\" (signal %d) signal to have been fired, but instead a %d signal was.",
+      broker.UptrendDetected, o.lastSignal,
+    )
+  }
+}
+
+func TestFiveMinuteRSICrossesDownThroughOverbought(t *testing.T) {
+  //
+  // Instantiate the algorithm.
+  //
+  o, err := New(5, 2, 30, 70)
+  if err != nil {
+    t.Fatalf("Failed to instantiate the algorithm. (Error: %s)", err)
+  }
+
+  //
+  // Seed the algorithm so that it becomes overbought (RSI ~93.33) without ever having been
+  // oversold.
+  //
+  if signal := seedAlgo(o, 100, 110, 105, 135); signal != broker.None {
+    t.Fatalf("Expected no signal while becoming overbought, but got signal %d.", signal)
+  }
+
+  //
+  // Simulate a pullback. Average gains and losses are smoothed to 8.75 and 5.625, for an RSI of
+  // ~60.87.
+  //
+  seedAlgo(o, 125)
+
+  if !o.rsiCur.LessThan(decimal.NewFromInt(70)) || !o.rsiPrev.GreaterThan(decimal.NewFromInt(70)) {
+    t.Errorf("Expected the RSI to have fallen through 70 but it went from %s to %s.", o.rsiPrev, o.rsiCur)
+  }
+
+  if o.lastSignal != broker.DowntrendDetected {
+    t.Errorf(
+      "Expected an \"Downtrend Detected\" (signal %d) signal to have been fired, but instead a %d signal was.",
+      broker.DowntrendDetected, o.lastSignal,
+    )
+  }
+}
+
+func TestRSIRejectsInvertedThresholds(t *testing.T) {
+  if _, err := New(5, 14, 70, 30); err == nil {
+    t.Errorf("Expected an oversold threshold above the overbought threshold to be rejected.")
+  }
+}
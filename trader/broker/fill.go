@@ -0,0 +1,73 @@
+package broker
+
+import (
+  "github.com/lukehollenback/goose/exchange"
+  "github.com/shopspring/decimal"
+  "time"
+)
+
+//
+// bar describes the range of prices that a market traded within over a period of time (e.g. a
+// candle), along with how much of the asset was traded.
+//
+type bar struct {
+  open   decimal.Decimal
+  high   decimal.Decimal
+  low    decimal.Decimal
+  close  decimal.Decimal
+  volume *decimal.Decimal // The quantity of the asset that was traded, or nil if it is not known.
+}
+
+//
+// fillModel describes how the mock trade executor fills orders. By default, orders are filled
+// instantly, in their entirety, at the price that triggered them.
+//
+type fillModel struct {
+  slippage      *Threshold      // How far the fill price moves against every order.
+  spread        decimal.Decimal // The fraction of a bar's high/low range that is paid as the bid/ask spread.
+  latency       time.Duration   // How long it takes orders to reach the market.
+  participation decimal.Decimal // The most of a bar's volume that an order may fill. Zero if uncapped.
+}
+
+//
+// price determines the price that an order on the provided side is filled at when the market is
+// trading at the provided reference price within the provided bar. Half of the spread is paid on
+// each side, and slippage always moves the price against the order.
+//
+// NOTE ~> Slippage is expressed the same way as a protective exit – as a threshold away from a
+//  reference price.
+//
+func (o *fillModel) price(side exchange.OrderSide, ref decimal.Decimal, within bar) decimal.Decimal {
+  adj := within.high.Sub(within.low).Mul(o.spread).Div(decimal.NewFromInt(2))
+
+  if o.slippage != nil {
+    adj = adj.Add(o.slippage.Of(ref))
+  }
+
+  if side == exchange.Buy {
+    return ref.Add(adj)
+  }
+
+  return decimal.Max(ref.Sub(adj), decimal.Zero)
+}
+
+//
+// capacity returns the most of the asset that an order may fill within the provided bar, and
+// whether or not it is limited at all.
+//
+func (o *fillModel) capacity(within bar) (decimal.Decimal, bool) {
+  if !o.participation.GreaterThan(decimal.Zero) || within.volume == nil {
+    return decimal.Zero, false
+  }
+
+  return within.volume.Mul(o.participation), true
+}
+
+//
+// realistic returns whether or not the fill model deviates from instant, complete fills at the
+// triggering price.
+//
+func (o *fillModel) realistic() bool {
+  return o.slippage != nil || o.spread.GreaterThan(decimal.Zero) || o.latency > 0 ||
+      o.participation.GreaterThan(decimal.Zero)
+}
@@ -0,0 +1,193 @@
+package broker
+
+import (
+  "github.com/lukehollenback/goose/exchange"
+  "github.com/lukehollenback/goose/trader/candle"
+  "github.com/shopspring/decimal"
+  "sync"
+  "testing"
+  "time"
+)
+
+func TestFillModelPrice(t *testing.T) {
+  slippage, _ := ParseThreshold("1%")
+  model := &fillModel{slippage: slippage, spread: decimal.NewFromFloat(0.5)}
+  within := bar{high: decimal.NewFromInt(104), low: decimal.NewFromInt(96)}
+
+  //
+  // Half of a 4 USD spread (half of the 8 USD range) is paid on each side, plus 1 USD of slippage.
+  //
+  if price := model.price(exchange.Buy, decimal.NewFromInt(100), within); !price.Equal(decimal.NewFromInt(103)) {
+    t.Errorf("Expected a buy to fill at 103 but it instead filled at %s.", price)
+  }
+
+  if price := model.price(exchange.Sell, decimal.NewFromInt(100), within); !price.Equal(decimal.NewFromInt(97)) {
+    t.Errorf("Expected a sell to fill at 97 but it instead filled at %s.", price)
+  }
+}
+
+func TestFillModelCapacity(t *testing.T) {
+  model := &fillModel{participation: decimal.NewFromFloat(0.1)}
+  volume := decimal.NewFromInt(50)
+
+  if capacity, capped := model.capacity(bar{volume: &volume}); !capped || !capacity.Equal(decimal.NewFromInt(5)) {
+    t.Errorf("Expected 10%% participation in 50 units of volume to cap fills at 5 but got %s (Capped: %t).", capacity, capped)
+  }
+
+  if _, capped := model.capacity(bar{}); capped {
+    t.Errorf("Expected fills to be uncapped when the volume is not known.")
+  }
+}
+
+//
+// newTestMockService instantiates a Broker Service that mock trades BTC with 1,000 USD, spending all
+// of it on every entry, without any fees.
+//
+func newTestMockService(fills *fillModel) *Service {
+  o := &Service{
+    mu:            &sync.Mutex{},
+    assets:        []string{"BTC"},
+    markets:       map[string]*market{"BTC": newMarket("BTC", "", 14)},
+    qtyPrecision:  8,
+    orderTimeout:  time.Hour,
+    ledger:        NewLedger(),
+    sizer:         FixedSizer{Amount: decimal.NewFromInt(1000)},
+    sizingCaps:    &sizingCaps{def: decimal.Zero, markets: make(map[string]decimal.Decimal)},
+    isMockTrading: true,
+    mockMakerFee:  decimal.Zero,
+    mockTakerFee:  decimal.Zero,
+    mockUSD:       decimal.NewFromInt(1000),
+    mockUSDInit:   decimal.NewFromInt(1000),
+    mockFills:     fills,
+  }
+
+  o.markets["BTC"].position = waiting
+
+  return o
+}
+
+func TestMockOrderFillsAfterLatencyWithinParticipation(t *testing.T) {
+  o := newTestMockService(&fillModel{latency: 10 * time.Second, participation: decimal.NewFromFloat(0.5)})
+
+  market := o.markets["BTC"]
+  start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+  volume := decimal.NewFromInt(10)
+
+  //
+  // The entry signal should not fill until the next candle, and then only up to half of its volume.
+  //
+  o.Signal("BTC", "test", UptrendDetected, decimal.NewFromInt(100), start)
+
+  if !market.mockHeld.IsZero() || market.position != buying {
+    t.Fatalf("Expected nothing to be filled before the order reaches the market.")
+  }
+
+  o.mark(market, bar{open: decimal.NewFromInt(50), high: decimal.NewFromInt(60), low: decimal.NewFromInt(40), close: decimal.NewFromInt(55), volume: &volume}, start.Add(time.Minute))
+
+  if !market.mockHeld.Equal(decimal.NewFromInt(5)) || !o.mockUSD.Equal(decimal.NewFromInt(750)) {
+    t.Fatalf("Expected 5 BTC to be filled at the next open of 50 but holdings are %s BTC and %s USD.", market.mockHeld, o.mockUSD)
+  }
+
+  o.mark(market, bar{open: decimal.NewFromInt(50), high: decimal.NewFromInt(60), low: decimal.NewFromInt(40), close: decimal.NewFromInt(55), volume: &volume}, start.Add(2*time.Minute))
+
+  if !market.mockHeld.Equal(decimal.NewFromInt(10)) || market.position != holding {
+    t.Errorf("Expected the remaining 5 BTC to be filled by the following candle but holdings are %s BTC.", market.mockHeld)
+  }
+}
+
+func TestMockLimitOrderRestsUntilTradedThrough(t *testing.T) {
+  o := newTestMockService(&fillModel{})
+  o.orderType = exchange.Limit
+  o.limitOffset, _ = ParseThreshold("10")
+  o.mockMakerFee = decimal.NewFromFloat(0.01)
+
+  market := o.markets["BTC"]
+  start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+  //
+  // The buy is quoted at 90, so it should rest through a candle that stays above it and then fill
+  // (as a maker) at 90 once a candle trades down through it.
+  //
+  o.Signal("BTC", "test", UptrendDetected, decimal.NewFromInt(100), start)
+  o.mark(market, bar{open: decimal.NewFromInt(100), high: decimal.NewFromInt(105), low: decimal.NewFromInt(95), close: decimal.NewFromInt(98)}, start.Add(time.Minute))
+
+  if !market.inFlight() || !market.mockHeld.IsZero() {
+    t.Fatalf("Expected the limit order to still be resting.")
+  }
+
+  o.mark(market, bar{open: decimal.NewFromInt(98), high: decimal.NewFromInt(99), low: decimal.NewFromInt(85), close: decimal.NewFromInt(88)}, start.Add(2*time.Minute))
+
+  if market.inFlight() || market.position != holding || !market.entryPrice.Equal(decimal.NewFromInt(90)) {
+    t.Fatalf("Expected the limit order to be filled at 90 but the entry price is %s.", market.entryPrice)
+  }
+
+  if fees := o.ledger.Fees(); !fees.Equal(market.entryPrice.Mul(market.mockHeld).Mul(o.mockMakerFee)) {
+    t.Errorf("Expected the maker fee to have been charged but %s USD of fees were.", fees)
+  }
+}
+
+func TestMockLimitOrderTimeInForce(t *testing.T) {
+  start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+  volume := decimal.NewFromInt(4)
+
+  //
+  // An immediate-or-cancel order that is not marketable is cancelled without filling anything.
+  //
+  o := newTestMockService(&fillModel{})
+  o.orderType = exchange.Limit
+  o.timeInForce = exchange.ImmediateOrCancel
+  o.limitOffset, _ = ParseThreshold("10")
+
+  o.Signal("BTC", "test", UptrendDetected, decimal.NewFromInt(100), start)
+
+  if market := o.markets["BTC"]; market.inFlight() || market.position != waiting {
+    t.Errorf("Expected an unmarketable IOC order to be cancelled.")
+  }
+
+  //
+  // A fill-or-kill order that can only be partially filled is killed without filling anything.
+  //
+  o = newTestMockService(&fillModel{participation: decimal.NewFromFloat(0.5)})
+  o.orderType = exchange.Limit
+  o.timeInForce = exchange.FillOrKill
+  o.markets["BTC"].bar = bar{open: decimal.NewFromInt(100), high: decimal.NewFromInt(100), low: decimal.NewFromInt(100), close: decimal.NewFromInt(100), volume: &volume}
+
+  o.Signal("BTC", "test", UptrendDetected, decimal.NewFromInt(100), start)
+
+  if market := o.markets["BTC"]; market.inFlight() || !market.mockHeld.IsZero() {
+    t.Errorf("Expected a FOK order that could only be partially filled to be killed.")
+  }
+
+  //
+  // A good-till-cancelled order rests until it is cancelled.
+  //
+  o = newTestMockService(&fillModel{})
+  o.orderType = exchange.Limit
+  o.limitOffset, _ = ParseThreshold("10")
+
+  o.Signal("BTC", "test", UptrendDetected, decimal.NewFromInt(100), start)
+
+  if !o.Cancel("BTC") || o.markets["BTC"].inFlight() || o.markets["BTC"].position != waiting {
+    t.Errorf("Expected a resting GTC order to be cancelable.")
+  }
+}
+
+func TestObservedCandlesMarkEquity(t *testing.T) {
+  o := newTestMockService(&fillModel{})
+
+  market := o.markets["BTC"]
+  market.mockHeld = decimal.NewFromInt(2)
+  market.lastPrice = decimal.NewFromInt(100)
+  o.mockUSD = decimal.Zero
+
+  c := candle.CreateFullCandle(
+    time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), time.Hour, decimal.NewFromInt(100), decimal.NewFromInt(120),
+    decimal.NewFromInt(125), decimal.NewFromInt(95), decimal.NewFromInt(10), decimal.NewFromInt(1),
+  )
+
+  o.ObserveCandle("BTC", c)
+
+  if equity := o.mockEquity(); !equity.Equal(decimal.NewFromInt(240)) {
+    t.Errorf("Expected 2 BTC to be marked at the candle's close of 120 USD but equity was %s.", equity)
+  }
+}
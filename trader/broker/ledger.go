@@ -0,0 +1,349 @@
+package broker
+
+import (
+  "encoding/csv"
+  "github.com/lukehollenback/goose/exchange"
+  "github.com/shopspring/decimal"
+  "io"
+  "sync"
+  "time"
+)
+
+//
+// Fill describes part (or all) of an order that the Broker Service placed being filled.
+//
+type Fill struct {
+  Time     time.Time
+  Asset    string
+  OrderID  string
+  Side     exchange.OrderSide
+  Price    decimal.Decimal
+  Qty      decimal.Decimal
+  Fee      decimal.Decimal // The fee (in USD) that the fill cost. Zero if the exchange did not report it.
+  Strategy string          // What caused the order to be placed (e.g. a strategy or a protective exit).
+  Signal   Signal          // The signal that caused the order to be placed.
+}
+
+//
+// Trade describes a position that was entered and then completely exited.
+//
+type Trade struct {
+  Asset    string
+  Strategy string // What caused the position to be entered.
+  Entered  time.Time
+  Exited   time.Time
+  Qty      decimal.Decimal // The quantity of the asset that was bought.
+  Cost     decimal.Decimal // The amount of USD (including fees) that was spent entering the position.
+  Proceeds decimal.Decimal // The amount of USD (net of fees) that was received exiting the position.
+  Fees     decimal.Decimal
+}
+
+//
+// PnL returns the profit (or loss) in USD that the trade made.
+//
+func (o Trade) PnL() decimal.Decimal {
+  return o.Proceeds.Sub(o.Cost)
+}
+
+//
+// Return returns the profit (or loss) that the trade made as a fraction of its cost (e.g. 0.02 for
+// 2%).
+//
+func (o Trade) Return() decimal.Decimal {
+  if !o.Cost.GreaterThan(decimal.Zero) {
+    return decimal.Zero
+  }
+
+  return o.Proceeds.Div(o.Cost).Sub(decimal.NewFromInt(1))
+}
+
+//
+// lot tracks a position that is open in a market, using average cost accounting.
+//
+type lot struct {
+  trade Trade           // The trade that the position will become once it has been completely exited.
+  qty   decimal.Decimal // The quantity of the asset that is still held.
+  cost  decimal.Decimal // The cost (including fees) of the quantity that is still held.
+}
+
+//
+// Ledger is an auditable record of every fill that the Broker Service has been told about. Realized
+// and unrealized profit and loss, as well as completed trades, are derived from it.
+//
+type Ledger struct {
+  mu       *sync.Mutex
+  fills    []Fill
+  trades   []Trade
+  lots     map[string]*lot
+  realized decimal.Decimal
+  fees     decimal.Decimal
+  journal  *csv.Writer // Where fills are written out as they are recorded, if anywhere.
+
+  unmatched []Fill // The parts of sells that had no open position to be matched against.
+}
+
+//
+// NewLedger instantiates an empty ledger.
+//
+func NewLedger() *Ledger {
+  return &Ledger{
+    mu:       &sync.Mutex{},
+    lots:     make(map[string]*lot),
+    realized: decimal.Zero,
+    fees:     decimal.Zero,
+  }
+}
+
+//
+// Journal tells the ledger to write every fill that it records from now on out to the provided
+// writer as a CSV row, starting with a header row.
+//
+func (o *Ledger) Journal(w io.Writer) error {
+  o.mu.Lock()
+  defer o.mu.Unlock()
+
+  o.journal = csv.NewWriter(w)
+
+  if err := o.journal.Write(fillHeader); err != nil {
+    return err
+  }
+
+  o.journal.Flush()
+
+  return o.journal.Error()
+}
+
+//
+// Record adds the provided fill to the ledger, updating the position that is open in its market.
+// If the fill completely exits the position, the position is recorded as a completed trade.
+//
+// NOTE ~> Any part of a sell that exceeds the open position (e.g. because the asset was already
+//  held before the ledger started recording) has no known cost, so it cannot count towards realized
+//  profit and loss or completed trades. It is logged and set aside as unmatched instead.
+//
+func (o *Ledger) Record(fill Fill) error {
+  o.mu.Lock()
+  defer o.mu.Unlock()
+
+  o.fills = append(o.fills, fill)
+  o.fees = o.fees.Add(fill.Fee)
+
+  open, ok := o.lots[fill.Asset]
+  quote := fill.Qty.Mul(fill.Price)
+
+  if fill.Side == exchange.Buy {
+    if !ok {
+      open = &lot{
+        trade: Trade{
+          Asset: fill.Asset, Strategy: fill.Strategy, Entered: fill.Time,
+          Qty: decimal.Zero, Cost: decimal.Zero, Proceeds: decimal.Zero, Fees: decimal.Zero,
+        },
+        qty:  decimal.Zero,
+        cost: decimal.Zero,
+      }
+
+      o.lots[fill.Asset] = open
+    }
+
+    open.qty = open.qty.Add(fill.Qty)
+    open.cost = open.cost.Add(quote).Add(fill.Fee)
+    open.trade.Qty = open.trade.Qty.Add(fill.Qty)
+    open.trade.Cost = open.trade.Cost.Add(quote).Add(fill.Fee)
+    open.trade.Fees = open.trade.Fees.Add(fill.Fee)
+  } else {
+    unmatched := fill.Qty
+
+    if ok && open.qty.GreaterThan(decimal.Zero) && fill.Qty.GreaterThan(decimal.Zero) {
+      //
+      // Realize the profit (or loss) of the portion of the position that was sold, as measured
+      // against its average cost. The fee is split between the sold portion and any excess.
+      //
+      sold := decimal.Min(fill.Qty, open.qty)
+      cost := open.cost.Mul(sold).Div(open.qty)
+      fee := fill.Fee.Mul(sold).Div(fill.Qty)
+      proceeds := sold.Mul(fill.Price).Sub(fee)
+
+      o.realized = o.realized.Add(proceeds.Sub(cost))
+
+      open.qty = open.qty.Sub(sold)
+      open.cost = open.cost.Sub(cost)
+      open.trade.Proceeds = open.trade.Proceeds.Add(proceeds)
+      open.trade.Fees = open.trade.Fees.Add(fee)
+
+      if !open.qty.GreaterThan(decimal.Zero) {
+        open.trade.Exited = fill.Time
+        o.trades = append(o.trades, open.trade)
+
+        delete(o.lots, fill.Asset)
+      }
+
+      unmatched = fill.Qty.Sub(sold)
+    }
+
+    if unmatched.GreaterThan(decimal.Zero) {
+      excess := fill
+      excess.Fee = fill.Fee.Mul(unmatched).Div(fill.Qty)
+      excess.Qty = unmatched
+
+      o.unmatched = append(o.unmatched, excess)
+
+      logger.Printf(
+        "Ledger has no open %s position to match %s of the %s sold by order %s against. It will not count "+
+            "towards realized PnL or completed trades.",
+        fill.Asset, unmatched, fill.Qty, fill.OrderID,
+      )
+    }
+  }
+
+  if o.journal == nil {
+    return nil
+  }
+
+  if err := o.journal.Write(fill.row()); err != nil {
+    return err
+  }
+
+  o.journal.Flush()
+
+  return o.journal.Error()
+}
+
+//
+// Unmatched returns the parts of every sell in the specified asset's market (or in every market if
+// no asset is specified) that had no open position to be matched against.
+//
+func (o *Ledger) Unmatched(asset string) []Fill {
+  o.mu.Lock()
+  defer o.mu.Unlock()
+
+  fills := make([]Fill, 0, len(o.unmatched))
+
+  for _, fill := range o.unmatched {
+    if asset == "" || fill.Asset == asset {
+      fills = append(fills, fill)
+    }
+  }
+
+  return fills
+}
+
+//
+// Fills returns every fill that has been recorded in the specified asset's market, or in every
+// market if no asset is specified.
+//
+func (o *Ledger) Fills(asset string) []Fill {
+  o.mu.Lock()
+  defer o.mu.Unlock()
+
+  fills := make([]Fill, 0, len(o.fills))
+
+  for _, fill := range o.fills {
+    if asset == "" || fill.Asset == asset {
+      fills = append(fills, fill)
+    }
+  }
+
+  return fills
+}
+
+//
+// Trades returns every trade that has been completed in the specified asset's market, or in every
+// market if no asset is specified.
+//
+func (o *Ledger) Trades(asset string) []Trade {
+  o.mu.Lock()
+  defer o.mu.Unlock()
+
+  trades := make([]Trade, 0, len(o.trades))
+
+  for _, trade := range o.trades {
+    if asset == "" || trade.Asset == asset {
+      trades = append(trades, trade)
+    }
+  }
+
+  return trades
+}
+
+//
+// RealizedPnL returns the profit (or loss) in USD that has been locked in by selling.
+//
+func (o *Ledger) RealizedPnL() decimal.Decimal {
+  o.mu.Lock()
+  defer o.mu.Unlock()
+
+  return o.realized
+}
+
+//
+// UnrealizedPnL returns the profit (or loss) in USD of the positions that are still open, marking
+// each to the provided price of its asset. Positions in assets without a price are ignored.
+//
+func (o *Ledger) UnrealizedPnL(marks map[string]decimal.Decimal) decimal.Decimal {
+  o.mu.Lock()
+  defer o.mu.Unlock()
+
+  pnl := decimal.Zero
+
+  for asset, open := range o.lots {
+    if mark, ok := marks[asset]; ok {
+      pnl = pnl.Add(open.qty.Mul(mark).Sub(open.cost))
+    }
+  }
+
+  return pnl
+}
+
+//
+// Fees returns the total fees (in USD) that every recorded fill has cost.
+//
+func (o *Ledger) Fees() decimal.Decimal {
+  o.mu.Lock()
+  defer o.mu.Unlock()
+
+  return o.fees
+}
+
+//
+// WriteFills writes every recorded fill out to the provided writer as CSV, starting with a header
+// row.
+//
+func (o *Ledger) WriteFills(w io.Writer) error {
+  rows := [][]string{fillHeader}
+
+  for _, fill := range o.Fills("") {
+    rows = append(rows, fill.row())
+  }
+
+  return csv.NewWriter(w).WriteAll(rows)
+}
+
+//
+// WriteTrades writes every completed trade out to the provided writer as CSV, starting with a
+// header row.
+//
+func (o *Ledger) WriteTrades(w io.Writer) error {
+  rows := [][]string{{"Asset", "Strategy", "Entered", "Exited", "Qty", "Cost", "Proceeds", "Fees", "PnL", "Return"}}
+
+  for _, trade := range o.Trades("") {
+    rows = append(rows, []string{
+      trade.Asset, trade.Strategy, trade.Entered.Format(time.RFC3339), trade.Exited.Format(time.RFC3339),
+      trade.Qty.String(), trade.Cost.String(), trade.Proceeds.String(), trade.Fees.String(), trade.PnL().String(),
+      trade.Return().String(),
+    })
+  }
+
+  return csv.NewWriter(w).WriteAll(rows)
+}
+
+var fillHeader = []string{"Time", "Asset", "OrderID", "Side", "Price", "Qty", "Fee", "Strategy", "Signal"}
+
+//
+// row returns the fill as a CSV row.
+//
+func (o Fill) row() []string {
+  return []string{
+    o.Time.Format(time.RFC3339), o.Asset, o.OrderID, o.Side.String(), o.Price.String(), o.Qty.String(),
+    o.Fee.String(), o.Strategy, o.Signal.String(),
+  }
+}
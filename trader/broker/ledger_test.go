@@ -0,0 +1,124 @@
+package broker
+
+import (
+  "bytes"
+  "github.com/lukehollenback/goose/exchange"
+  "github.com/shopspring/decimal"
+  "strings"
+  "testing"
+  "time"
+)
+
+func TestLedgerPnL(t *testing.T) {
+  ledger := NewLedger()
+  start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+  fill := func(minutes int, side exchange.OrderSide, price int64, qty int64, fee int64) {
+    err := ledger.Record(Fill{
+      Time: start.Add(time.Duration(minutes) * time.Minute), Asset: "BTC", Side: side, Price: decimal.NewFromInt(price),
+      Qty: decimal.NewFromInt(qty), Fee: decimal.NewFromInt(fee), Strategy: "test", Signal: UptrendDetected,
+    })
+    if err != nil {
+      t.Fatalf("Failed to record fill. (Error: %s)", err)
+    }
+  }
+
+  //
+  // Buy 2 BTC at an average cost of 101 USD (including fees), and then sell half of it at 120.
+  //
+  fill(0, exchange.Buy, 100, 1, 1)
+  fill(1, exchange.Buy, 100, 1, 1)
+  fill(2, exchange.Sell, 120, 1, 0)
+
+  if realized := ledger.RealizedPnL(); !realized.Equal(decimal.NewFromInt(19)) {
+    t.Errorf("Expected 19 USD of realized PnL but got %s.", realized)
+  }
+
+  marks := map[string]decimal.Decimal{"BTC": decimal.NewFromInt(91)}
+
+  if unrealized := ledger.UnrealizedPnL(marks); !unrealized.Equal(decimal.NewFromInt(-10)) {
+    t.Errorf("Expected -10 USD of unrealized PnL but got %s.", unrealized)
+  }
+
+  if trades := ledger.Trades(""); len(trades) != 0 {
+    t.Fatalf("Expected no trades to be completed while the position is still open.")
+  }
+
+  //
+  // Sell the rest of the position, completing the trade.
+  //
+  fill(3, exchange.Sell, 81, 1, 0)
+
+  trades := ledger.Trades("BTC")
+  if len(trades) != 1 {
+    t.Fatalf("Expected one completed trade but got %d.", len(trades))
+  }
+
+  if pnl := trades[0].PnL(); !pnl.Equal(decimal.NewFromInt(-1)) {
+    t.Errorf("Expected the trade to have lost 1 USD but it made %s.", pnl)
+  }
+
+  if ret := trades[0].Return(); !ret.Equal(decimal.NewFromInt(-1).Div(decimal.NewFromInt(202))) {
+    t.Errorf("Expected the trade to have returned -1/202 but it returned %s.", ret)
+  }
+
+  if fees := ledger.Fees(); !fees.Equal(decimal.NewFromInt(2)) {
+    t.Errorf("Expected 2 USD of fees but got %s.", fees)
+  }
+
+  //
+  // Export the fills.
+  //
+  var buf bytes.Buffer
+
+  if err := ledger.WriteFills(&buf); err != nil {
+    t.Fatalf("Failed to export fills. (Error: %s)", err)
+  }
+
+  if rows := strings.Split(strings.TrimSpace(buf.String()), "\n"); len(rows) != 5 {
+    t.Errorf("Expected a header row and four fill rows but got %d rows.", len(rows))
+  }
+}
+
+func TestLedgerUnmatchedSells(t *testing.T) {
+  ledger := NewLedger()
+  start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+  fill := func(minutes int, side exchange.OrderSide, price int64, qty int64, fee int64) {
+    err := ledger.Record(Fill{
+      Time: start.Add(time.Duration(minutes) * time.Minute), Asset: "BTC", Side: side, Price: decimal.NewFromInt(price),
+      Qty: decimal.NewFromInt(qty), Fee: decimal.NewFromInt(fee), Strategy: "test", Signal: DowntrendDetected,
+    })
+    if err != nil {
+      t.Fatalf("Failed to record fill. (Error: %s)", err)
+    }
+  }
+
+  //
+  // Sell 1 BTC that was held before the ledger started recording, and then buy 1 BTC and sell 3
+  // (with 3 USD of fees), only the first of which can be matched.
+  //
+  fill(0, exchange.Sell, 100, 1, 0)
+  fill(1, exchange.Buy, 100, 1, 0)
+  fill(2, exchange.Sell, 110, 3, 3)
+
+  unmatched := ledger.Unmatched("BTC")
+  if len(unmatched) != 2 || !unmatched[0].Qty.Equal(decimal.NewFromInt(1)) || !unmatched[1].Qty.Equal(decimal.NewFromInt(2)) {
+    t.Fatalf("Expected 1 BTC and then 2 BTC of unmatched sells but got %v.", unmatched)
+  }
+
+  if !unmatched[1].Fee.Equal(decimal.NewFromInt(2)) {
+    t.Errorf("Expected 2 USD of fees to be attributed to the unmatched part of the sell but got %s.", unmatched[1].Fee)
+  }
+
+  //
+  // Only the matched part of the sell (at 110, less 1 USD of fees) counts towards realized PnL.
+  //
+  if realized := ledger.RealizedPnL(); !realized.Equal(decimal.NewFromInt(9)) {
+    t.Errorf("Expected 9 USD of realized PnL but got %s.", realized)
+  }
+
+  if trades := ledger.Trades("BTC"); len(trades) != 1 || !trades[0].PnL().Equal(decimal.NewFromInt(9)) {
+    t.Errorf("Expected one completed trade that made 9 USD but got %v.", trades)
+  }
+}
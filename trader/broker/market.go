@@ -0,0 +1,105 @@
+package broker
+
+import (
+  "github.com/lukehollenback/goose/trader/indicators"
+  "github.com/shopspring/decimal"
+  "time"
+)
+
+//
+// market represents the Broker Service's view of a single market that it is trading in. Every
+// market tracks its own position, but all markets share the same quote (USD) balance.
+//
+type market struct {
+  asset     string
+  symbol    string          // The symbol of the market as expected by the REST API client.
+  position  position
+  held      decimal.Decimal // The most recently-reconciled free balance of the asset in the real account.
+  mockHeld  decimal.Decimal // The amount of the asset that the mock trade executor is holding.
+  lastPrice decimal.Decimal // The most recent price (i.e. trade or candle close) of the asset that the Broker Service has been told about.
+  order     *order          // The order that is currently in flight in the market, if any.
+  bar       bar             // The most recent range of prices that the Broker Service has been told about.
+
+  busy     bool            // Whether or not a live order is being acted upon (e.g. placed or cancelled).
+  deferred *deferredSignal // The most recent signal that came in while the market was busy, if any.
+
+  entryPrice decimal.Decimal // The price at which the current position was entered.
+  peakPrice  decimal.Decimal // The highest price that has been seen since the current position was entered.
+
+  atr  *indicators.ATR // The average true range of the market, for use when sizing positions.
+  hint SizeHint        // The most recent sizing advice from a strategy that trades the market.
+}
+
+//
+// deferredSignal is a signal that came in while its market was busy, and which is to be acted upon
+// once the market is no longer busy.
+//
+type deferredSignal struct {
+  source    string
+  signal    Signal
+  price     decimal.Decimal
+  timestamp time.Time
+  maxSpend  *decimal.Decimal
+}
+
+//
+// inFlight returns whether or not an order is currently in flight in the market.
+//
+func (o *market) inFlight() bool {
+  return o.order != nil
+}
+
+//
+// newMarket instantiates a new market for the provided asset.
+//
+func newMarket(asset string, symbol string, atrPeriod int) *market {
+  //
+  // NOTE ~> The period of the average true range has already been validated by the time that any
+  //  market is instantiated.
+  //
+  atr, _ := indicators.NewATR(atrPeriod, indicators.Unrounded)
+
+  return &market{
+    asset:     asset,
+    symbol:    symbol,
+    position:  offline,
+    held:      decimal.Zero,
+    mockHeld:  decimal.Zero,
+    lastPrice: decimal.Zero,
+
+    entryPrice: decimal.Zero,
+    peakPrice:  decimal.Zero,
+
+    atr:  atr,
+    hint: SizeHint{ATR: decimal.Zero},
+  }
+}
+
+//
+// sizingATR returns the average true range that new positions in the market should be sized
+// against. A strategy's hint takes precedence over the average true range tracked by the Broker
+// Service itself.
+//
+func (o *market) sizingATR() decimal.Decimal {
+  if o.hint.ATR.GreaterThan(decimal.Zero) {
+    return o.hint.ATR
+  }
+
+  return o.atr.Value()
+}
+
+//
+// enter records that a position has been entered in the market at the provided price.
+//
+func (o *market) enter(price decimal.Decimal) {
+  o.entryPrice = price
+  o.peakPrice = price
+}
+
+//
+// exit records that the position that was held in the market has been exited.
+//
+func (o *market) exit() {
+  o.entryPrice = decimal.Zero
+  o.peakPrice = decimal.Zero
+}
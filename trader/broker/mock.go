@@ -0,0 +1,349 @@
+package broker
+
+import (
+  "fmt"
+  "github.com/logrusorgru/aurora"
+  "github.com/lukehollenback/goose/exchange"
+  "github.com/lukehollenback/goose/trader/writer"
+  "github.com/shopspring/decimal"
+  "time"
+)
+
+//
+// executeMockTrade enters or exits a position in the provided market using the mock trade executor
+// depending on the signal that came in. Orders are filled as described by the mock fill model –
+// immediately at the price that triggered them unless latency delays them until later candles or
+// participation limits spread them out over several. Limit orders that are not marketable when they
+// reach the market rest until later candles trade through their price.
+//
+// NOTE ~> Mock orders that are already in flight are dealt with the same way that live ones are. A
+//  signal in the same direction is ignored, while a signal in the opposite direction cancels the
+//  order and is then acted upon against whatever position the partial fill (if any) left us in.
+//
+func (o *Service) executeMockTrade(market *market, source string, signal Signal, price decimal.Decimal, timestamp time.Time, maxSpend *decimal.Decimal) {
+  //
+  // Deal with any order that is already in flight.
+  //
+  if market.inFlight() {
+    if (signal == UptrendDetected && market.order.side == exchange.Buy) ||
+        (signal == DowntrendDetected && market.order.side == exchange.Sell) {
+      logger.Printf(
+        "Ignoring %s signal (at %s) because mock %s order %s is already in flight.",
+        market.asset, price, market.order.side, market.order.id,
+      )
+
+      return
+    }
+
+    if signal != UptrendDetected && signal != DowntrendDetected {
+      return
+    }
+
+    logger.Printf(
+      "Cancelling mock %s order %s because an opposing %s signal (at %s) came in.",
+      market.order.side, market.order.id, market.asset, price,
+    )
+
+    o.finishMockOrder(market, cancelled, timestamp)
+  }
+
+  //
+  // Determine which side of the order book we need to be on and how much of the asset to trade.
+  //
+  var side exchange.OrderSide
+  var qty decimal.Decimal
+
+  if signal == UptrendDetected && market.position == waiting {
+    spend := o.positionSize(market, price, o.mockUSD, maxSpend)
+
+    if !spend.GreaterThan(decimal.Zero) {
+      logger.Printf("Skipping %s entry (at %s) because the position size is zero.", market.asset, price)
+
+      return
+    }
+
+    side = exchange.Buy
+    qty = spend.Div(o.quote(side, price).Mul(decimal.NewFromInt(1).Add(o.mockTakerFee)))
+  } else if signal == DowntrendDetected && market.position == holding {
+    side = exchange.Sell
+    qty = market.mockHeld
+  } else {
+    return
+  }
+
+  //
+  // Place the mock order.
+  //
+  o.mockOrderCnt++
+
+  order := newOrder(side, source, signal, qty, o.quote(side, price), time.Time{})
+  order.id = fmt.Sprintf("mock-%d", o.mockOrderCnt)
+  order.arrives = timestamp.Add(o.mockFills.latency)
+
+  if o.orderType == exchange.Limit {
+    order.deadline = order.arrives.Add(o.orderTimeout)
+  }
+
+  market.order = order
+
+  if side == exchange.Buy {
+    market.position = buying
+  } else {
+    market.position = selling
+  }
+
+  o.recordTradeTime()
+
+  //
+  // Without any latency, the order reaches the market immediately and begins to fill at the price
+  // that triggered it. Otherwise, it waits for a later candle.
+  //
+  logger.Printf(
+    "Placed mock %s %s order %s for %s %s (at %s). It will reach the market at %s.",
+    o.orderType, side, order.id, qty, market.asset, order.price, order.arrives,
+  )
+
+  if o.mockFills.latency <= 0 {
+    o.fillMockOrder(market, price, market.bar, false, timestamp)
+  }
+}
+
+//
+// fillMockOrder fills as much of the mock order that is in flight in the provided market as the
+// mock fill model allows, given that the market is trading at the provided reference price within
+// the provided bar. The bar is only treated as having traded while the order was resting on the
+// order book if it closed out after the order reached the market.
+//
+// NOTE ~> Time in force only applies to limit orders, and only to the first attempt at filling them
+//  after they reach the market.
+//
+func (o *Service) fillMockOrder(market *market, ref decimal.Decimal, within bar, resting bool, timestamp time.Time) {
+  order := market.order
+  one := decimal.NewFromInt(1)
+  first := !order.arrived
+
+  //
+  // Work out how much of the order can be filled, and at what price.
+  //
+  price, maker, ok := o.mockFillPrice(order, ref, within, resting)
+  order.arrived = true
+
+  qty := order.remaining()
+
+  if capacity, capped := o.mockFills.capacity(within); capped && qty.GreaterThan(capacity) {
+    qty = capacity
+  }
+
+  immediate := first && o.orderType == exchange.Limit
+
+  if immediate && o.timeInForce == exchange.FillOrKill && (!ok || qty.LessThan(order.remaining())) {
+    logger.Printf("Killing mock %s order %s because it could not be completely filled immediately.", order.side, order.id)
+
+    o.finishMockOrder(market, cancelled, timestamp)
+
+    return
+  }
+
+  if !ok || !qty.GreaterThan(decimal.Zero) || !price.GreaterThan(decimal.Zero) {
+    if immediate && o.timeInForce == exchange.ImmediateOrCancel {
+      o.finishMockOrder(market, cancelled, timestamp)
+    }
+
+    return
+  }
+
+  feeRate := o.mockTakerFee
+  liquidity := "taker"
+
+  if maker {
+    feeRate = o.mockMakerFee
+    liquidity = "maker"
+  }
+
+  //
+  // Buys can never spend more than the free USD balance. If the price has moved against the order,
+  // it is shrunk down to whatever can still be afforded.
+  //
+  if order.side == exchange.Buy {
+    affordable := o.mockUSD.Div(price.Mul(one.Add(feeRate))).Truncate(o.qtyPrecision)
+
+    if qty.GreaterThan(affordable) {
+      qty = affordable
+      order.qty = order.filledQty.Add(qty)
+    }
+  }
+
+  //
+  // Execute the mock transaction.
+  //
+  quote := qty.Mul(price)
+  fee := quote.Mul(feeRate)
+
+  if order.side == exchange.Buy {
+    o.mockUSD = o.mockUSD.Sub(quote).Sub(fee)
+    market.mockHeld = market.mockHeld.Add(qty)
+  } else {
+    o.mockUSD = o.mockUSD.Add(quote).Sub(fee)
+    market.mockHeld = market.mockHeld.Sub(qty)
+  }
+
+  order.filledQty = order.filledQty.Add(qty)
+  order.filledQuote = order.filledQuote.Add(quote)
+  order.fees = order.fees.Add(fee)
+
+  o.journal(market, order, fee, timestamp)
+
+  logger.Printf(
+    "Mock %s order %s filled %s of %s %s (at %s, as %s). Fees were %s.",
+    order.side, order.id, aurora.Bold(aurora.Yellow(qty.String())), order.qty, market.asset, price, liquidity,
+    aurora.Bold(aurora.Blue(fmt.Sprintf("%s USD", fee))),
+  )
+
+  //
+  // Move the order along in its lifecycle.
+  //
+  if !order.remaining().GreaterThan(decimal.Zero) {
+    o.finishMockOrder(market, filled, timestamp)
+  } else if immediate && o.timeInForce == exchange.ImmediateOrCancel {
+    o.finishMockOrder(market, cancelled, timestamp)
+  } else if order.state == submitted {
+    _ = order.transition(partiallyFilled)
+  }
+}
+
+//
+// mockFillPrice determines the price that the provided mock order can be filled at given that the
+// market is trading at the provided reference price within the provided bar, and whether or not
+// the fill rested on the order book (i.e. is a maker fill). Returns false if the order cannot be
+// filled at all.
+//
+// NOTE ~> A limit order that is marketable when it reaches the market takes from the order book
+//  like a market order would, but never at a worse price than its limit. Otherwise, it rests on
+//  the order book and is filled at its limit once a bar trades through it – or at the bar's open if
+//  the price gapped through it.
+//
+func (o *Service) mockFillPrice(order *order, ref decimal.Decimal, within bar, resting bool) (decimal.Decimal, bool, bool) {
+  taker := o.mockFills.price(order.side, ref, within)
+
+  if o.orderType != exchange.Limit {
+    return taker, false, true
+  }
+
+  buy := order.side == exchange.Buy
+
+  if !order.arrived {
+    if buy && !ref.GreaterThan(order.price) {
+      return decimal.Min(taker, order.price), false, true
+    } else if !buy && !ref.LessThan(order.price) {
+      return decimal.Max(taker, order.price), false, true
+    }
+  }
+
+  if !resting {
+    return decimal.Zero, false, false
+  }
+
+  if buy && !within.low.GreaterThan(order.price) {
+    return decimal.Min(within.open, order.price), true, true
+  } else if !buy && !within.high.LessThan(order.price) {
+    return decimal.Max(within.open, order.price), true, true
+  }
+
+  return decimal.Zero, false, false
+}
+
+//
+// expireMockOrder deals with a mock limit order that did not completely fill before its deadline.
+// It is re-quoted at the latest price until it runs out of re-quotes, and is then cancelled.
+//
+// NOTE ~> Unlike live orders, re-quoted mock orders keep their identity (and fills) rather than
+//  being replaced by new orders.
+//
+func (o *Service) expireMockOrder(market *market, timestamp time.Time) {
+  order := market.order
+
+  logger.Printf("Mock %s order %s did not fill before its deadline.", order.side, order.id)
+
+  if order.requotes >= o.maxRequotes {
+    o.finishMockOrder(market, timedOut, timestamp)
+
+    return
+  }
+
+  order.requotes++
+  order.price = o.quote(order.side, market.lastPrice)
+  order.deadline = timestamp.Add(o.orderTimeout)
+
+  logger.Printf(
+    "Re-quoting mock %s order %s for %s %s at %s (re-quote %d of %d).",
+    order.side, order.id, order.remaining(), market.asset, order.price, order.requotes, o.maxRequotes,
+  )
+}
+
+//
+// finishMockOrder moves the mock order that is in flight in the provided market into the provided
+// final state, stops tracking it, and works out where it left our position.
+//
+func (o *Service) finishMockOrder(market *market, state orderState, timestamp time.Time) {
+  order := market.order
+
+  if order.state != state {
+    if err := order.transition(state); err != nil {
+      order.state = state
+    }
+  }
+
+  market.order = nil
+
+  gainMsg := ""
+
+  if order.side == exchange.Buy && order.filledQty.GreaterThan(decimal.Zero) {
+    market.position = holding
+    market.enter(order.filledQuote.Div(order.filledQty))
+  } else if order.side == exchange.Buy {
+    market.position = waiting
+  } else {
+    //
+    // If only part of the position was sold, the rest of it is still being held.
+    //
+    if market.mockHeld.GreaterThan(decimal.Zero) {
+      market.position = holding
+    } else {
+      market.position = waiting
+      market.exit()
+    }
+
+    //
+    // Since we are now holding USD again, build out a message that explains the current running
+    // USD gain/loss. Also, notify the Writer Service so that it can track the data point if it
+    // cares.
+    //
+    o.mockUSDGain = o.mockEquity().Sub(o.mockUSDInit)
+
+    if o.mockUSDGain.GreaterThan(decimal.Zero) {
+      gainMsg = fmt.Sprintf(
+        "Total running gain/loss is %s.",
+        aurora.Bold(aurora.Green(fmt.Sprintf("%s USD", o.mockUSDGain))),
+      )
+    } else {
+      gainMsg = fmt.Sprintf(
+        "Total running gain/loss is %s.",
+        aurora.Bold(aurora.Red(fmt.Sprintf("%s USD", o.mockUSDGain))),
+      )
+    }
+
+    _ = writer.Instance().Write(timestamp, writer.GrossMockEarnings, o.mockUSDGain)
+  }
+
+  //
+  // Log details about the current position now that the mock trade has been executed.
+  //
+  logger.Printf(
+    "Mock %s order %s is finished as %s (Filled: %s of %s %s)! Current holdings are %s and %s. Fees were %s. %s",
+    order.side, order.id, order.state, order.filledQty, order.qty, market.asset,
+    aurora.Bold(aurora.Yellow(fmt.Sprintf("%s %s", market.mockHeld, market.asset))),
+    aurora.Bold(aurora.Green(fmt.Sprintf("%s USD", o.mockUSD))),
+    aurora.Bold(aurora.Blue(fmt.Sprintf("%s USD", order.fees))),
+    gainMsg,
+  )
+}
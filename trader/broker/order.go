@@ -0,0 +1,159 @@
+package broker
+
+import (
+  "fmt"
+  "github.com/lukehollenback/goose/exchange"
+  "github.com/shopspring/decimal"
+  "time"
+)
+
+//
+// orderState is an enum that represents where an order that the Broker Service has placed is in
+// its lifecycle.
+//
+type orderState int
+
+const (
+  submitted       orderState = iota // The order has been placed, but nothing has been filled yet.
+  partiallyFilled                   // Some, but not all, of the order has been filled.
+  filled                            // The order has been completely filled.
+  cancelled                         // The order was cancelled before it was completely filled.
+  rejected                          // The order was rejected by the exchange.
+  timedOut                          // The order did not fill before its deadline and was given up on.
+)
+
+func (o orderState) String() string {
+  return [...]string{"submitted", "partially filled", "filled", "cancelled", "rejected", "timed out"}[o]
+}
+
+//
+// final returns whether or not the order state is the last state that an order can be in.
+//
+func (o orderState) final() bool {
+  return o == filled || o == cancelled || o == rejected || o == timedOut
+}
+
+//
+// orderTransitions describes which states an order may move into from each non-final state.
+//
+var orderTransitions = map[orderState][]orderState{
+  submitted:       {partiallyFilled, filled, cancelled, rejected, timedOut},
+  partiallyFilled: {filled, cancelled, timedOut},
+}
+
+//
+// orderStates maps the statuses that exchanges report orders in to order states. Statuses that are
+// not mapped (e.g. pending cancellation) do not move orders out of their current state.
+//
+var orderStates = map[exchange.OrderStatus]orderState{
+  exchange.New:             submitted,
+  exchange.PartiallyFilled: partiallyFilled,
+  exchange.Filled:          filled,
+  exchange.Canceled:        cancelled,
+  exchange.Rejected:        rejected,
+  exchange.Expired:         cancelled,
+}
+
+//
+// order represents an order that the Broker Service has placed and is tracking through its
+// lifecycle.
+//
+type order struct {
+  id          string
+  side        exchange.OrderSide
+  source      string          // What caused the order to be placed (e.g. a strategy or a protective exit).
+  signal      Signal          // The signal that caused the order to be placed.
+  qty         decimal.Decimal // The quantity of the asset that was ordered.
+  price       decimal.Decimal // The price that the order was quoted at.
+  filledQty   decimal.Decimal // The quantity of the asset that has been filled so far.
+  filledQuote decimal.Decimal // The quantity of the quote asset that has been filled so far.
+  state       orderState
+  deadline    time.Time // When the order will be re-quoted or cancelled if it has not filled.
+  requotes    int       // The number of times that the order has been re-quoted.
+
+  arrives time.Time       // When a mock order reaches the market and may begin to fill.
+  arrived bool            // Whether or not a mock order has had its first chance to fill.
+  resting bool            // Whether or not a live order has come to rest on the order book.
+  fees    decimal.Decimal // The fees (in USD) that filling the order has cost (or is estimated to have cost) so far.
+
+  journaledQty   decimal.Decimal // The quantity of the asset that has been recorded in the ledger so far.
+  journaledQuote decimal.Decimal // The quantity of the quote asset that has been recorded in the ledger so far.
+}
+
+//
+// newOrder begins tracking an order that has just been placed.
+//
+func newOrder(side exchange.OrderSide, source string, signal Signal, qty decimal.Decimal, price decimal.Decimal, deadline time.Time) *order {
+  return &order{
+    side:           side,
+    source:         source,
+    signal:         signal,
+    qty:            qty,
+    price:          price,
+    filledQty:      decimal.Zero,
+    filledQuote:    decimal.Zero,
+    fees:           decimal.Zero,
+    journaledQty:   decimal.Zero,
+    journaledQuote: decimal.Zero,
+    state:          submitted,
+    deadline:       deadline,
+  }
+}
+
+//
+// transition moves the order into the provided state. Returns an error if the order cannot move into
+// that state from its current one.
+//
+func (o *order) transition(to orderState) error {
+  for _, allowed := range orderTransitions[o.state] {
+    if allowed == to {
+      o.state = to
+
+      return nil
+    }
+  }
+
+  return fmt.Errorf("order %s cannot move from %s to %s", o.id, o.state, to)
+}
+
+//
+// update synchronizes the order with the latest view of it that the exchange has provided. Returns
+// whether or not the order's state or fills changed.
+//
+func (o *order) update(exOrder exchange.Order) (bool, error) {
+  prevState := o.state
+  prevFilledQty := o.filledQty
+
+  if exOrder.ID() != "" {
+    o.id = exOrder.ID()
+  }
+
+  //
+  // Move the order into its new state (if it has one).
+  //
+  if to, ok := orderStates[exOrder.Status()]; ok && to != o.state {
+    if err := o.transition(to); err != nil {
+      return false, err
+    }
+  }
+
+  //
+  // Capture how much of the order has been filled.
+  //
+  if exOrder.FilledQuantity() != nil {
+    o.filledQty = *exOrder.FilledQuantity()
+  }
+
+  if exOrder.FilledQuoteQuantity() != nil {
+    o.filledQuote = *exOrder.FilledQuoteQuantity()
+  }
+
+  return o.state != prevState || !o.filledQty.Equal(prevFilledQty), nil
+}
+
+//
+// remaining returns the quantity of the asset that has not been filled yet.
+//
+func (o *order) remaining() decimal.Decimal {
+  return o.qty.Sub(o.filledQty)
+}
@@ -0,0 +1,92 @@
+package broker
+
+import (
+  "github.com/lukehollenback/goose/exchange"
+  "github.com/shopspring/decimal"
+  "testing"
+  "time"
+)
+
+//
+// stubOrder is a minimal exchange order whose status and fills can be set directly.
+//
+type stubOrder struct {
+  status    exchange.OrderStatus
+  filledQty decimal.Decimal
+}
+
+func (o *stubOrder) ID() string                            { return "1" }
+func (o *stubOrder) Symbol() string                        { return "BTCUSD" }
+func (o *stubOrder) Side() exchange.OrderSide              { return exchange.Buy }
+func (o *stubOrder) Type() exchange.OrderType              { return exchange.Limit }
+func (o *stubOrder) Status() exchange.OrderStatus          { return o.status }
+func (o *stubOrder) TimeInForce() exchange.TimeInForce     { return exchange.GoodTillCanceled }
+func (o *stubOrder) Time() *time.Time                      { return nil }
+func (o *stubOrder) Price() *decimal.Decimal               { return nil }
+func (o *stubOrder) Quantity() *decimal.Decimal            { return nil }
+func (o *stubOrder) FilledQuantity() *decimal.Decimal      { return &o.filledQty }
+func (o *stubOrder) FilledQuoteQuantity() *decimal.Decimal { return nil }
+
+func TestOrderLifecycle(t *testing.T) {
+  order := newOrder(exchange.Buy, "test", UptrendDetected, decimal.NewFromInt(2), decimal.NewFromInt(100), time.Now())
+
+  //
+  // Partially fill the order, and then fill it some more.
+  //
+  if changed, err := order.update(&stubOrder{exchange.PartiallyFilled, decimal.NewFromInt(1)}); err != nil || !changed {
+    t.Fatalf("Expected the order to be partially filled. (Changed: %t, Error: %v)", changed, err)
+  }
+
+  if changed, err := order.update(&stubOrder{exchange.PartiallyFilled, decimal.NewFromFloat(1.5)}); err != nil || !changed {
+    t.Fatalf("Expected the order's fills to change. (Changed: %t, Error: %v)", changed, err)
+  }
+
+  if !order.remaining().Equal(decimal.NewFromFloat(0.5)) {
+    t.Errorf("Expected 0.5 to remain unfilled but instead %s did.", order.remaining())
+  }
+
+  //
+  // An order cannot go back to being freshly submitted once it has been partially filled.
+  //
+  if _, err := order.update(&stubOrder{exchange.New, decimal.Zero}); err == nil {
+    t.Errorf("Expected a partially filled order to not be able to move back to being submitted.")
+  }
+
+  //
+  // Finish the order, after which it cannot move anywhere else.
+  //
+  if _, err := order.update(&stubOrder{exchange.Filled, decimal.NewFromInt(2)}); err != nil || order.state != filled {
+    t.Fatalf("Expected the order to be filled but it was instead %s. (Error: %v)", order.state, err)
+  }
+
+  if err := order.transition(cancelled); err == nil {
+    t.Errorf("Expected a filled order to not be able to be cancelled.")
+  }
+}
+
+func TestLiveFillsAreJournaledWithEstimatedFees(t *testing.T) {
+  o := &Service{
+    ledger:       NewLedger(),
+    liveMakerFee: decimal.NewFromFloat(0.001),
+    liveTakerFee: decimal.NewFromFloat(0.002),
+  }
+
+  market := newMarket("BTC", "BTCUSD", 14)
+  order := newOrder(exchange.Buy, "test", UptrendDetected, decimal.NewFromInt(2), decimal.NewFromInt(100), time.Now())
+
+  //
+  // The first unit fills immediately (as a taker), and the second only after the order has come to
+  // rest on the order book (as a maker).
+  //
+  order.update(&stubOrder{exchange.PartiallyFilled, decimal.NewFromInt(1)})
+  o.journalLive(market, order)
+
+  order.resting = true
+
+  order.update(&stubOrder{exchange.Filled, decimal.NewFromInt(2)})
+  o.journalLive(market, order)
+
+  if fees := o.ledger.Fees(); !fees.Equal(decimal.NewFromFloat(0.3)) {
+    t.Errorf("Expected 0.3 USD of fees (0.2 as a taker and 0.1 as a maker) but got %s.", fees)
+  }
+}
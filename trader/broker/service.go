@@ -1,24 +1,63 @@
 package broker
 
 import (
+  "errors"
+  "flag"
   "fmt"
   "github.com/logrusorgru/aurora"
   "github.com/lukehollenback/goose/constants"
-  "github.com/lukehollenback/goose/trader/writer"
+  "github.com/lukehollenback/goose/exchange"
+  "github.com/lukehollenback/goose/trader/candle"
+  "github.com/lukehollenback/goose/trader/indicators"
   "github.com/shopspring/decimal"
   "log"
+  "os"
+  "path/filepath"
   "sync"
   "time"
 )
 
 const (
-  Name = "≪broker-service≫"
+  Name       = "≪broker-service≫"
+  QuoteAsset = "USD"
 )
 
 var (
   o      *Service
   once   sync.Once
   logger *log.Logger
+
+  cfgOrderType     *string
+  cfgOrderTimeout  *time.Duration
+  cfgOrderPoll     *time.Duration
+  cfgOrderRequotes *int
+  cfgOrderTIF      *string
+  cfgOrderOffset   *string
+  cfgStopLoss      *string
+  cfgTakeProfit    *string
+  cfgTrailingStop  *string
+
+  cfgSizing          *string
+  cfgSizingAmt       *float64
+  cfgSizingFraction  *float64
+  cfgSizingRisk      *float64
+  cfgSizingATRPeriod *int
+  cfgSizingATRMult   *float64
+  cfgSizingATRIntvl  *time.Duration
+  cfgSizingKellyScl  *float64
+  cfgSizingKellyMin  *int
+  cfgSizingMax       *string
+
+  cfgMockSlippage      *string
+  cfgMockSpread        *float64
+  cfgMockLatency       *time.Duration
+  cfgMockParticipation *float64
+
+  cfgLiveFee      *float64
+  cfgLiveMakerFee *float64
+  cfgLiveTakerFee *float64
+
+  cfgLedgerDir *string
 )
 
 func init() {
@@ -26,6 +65,193 @@ func init() {
   // Initialize the logger.
   //
   logger = log.New(log.Writer(), fmt.Sprintf(constants.LogPrefixFmt, Name), log.Ldate | log.Ltime | log.Lmsgprefix)
+
+  //
+  // Register and parse configuration flags.
+  //
+  cfgOrderType = flag.String(
+    "order-type",
+    "market",
+    "The type of order that should be placed. Valid values are market and limit. Limit orders are quoted at "+
+        "the price that triggered the signal, less any offset.",
+  )
+
+  cfgOrderTIF = flag.String(
+    "order-tif",
+    "GTC",
+    "How long limit orders remain active. Valid values are GTC (until filled, cancelled, or timed out), IOC "+
+        "(whatever fills immediately, with the rest cancelled), and FOK (completely and immediately, or not at all).",
+  )
+
+  cfgOrderOffset = flag.String(
+    "order-limit-offset",
+    "",
+    "How far below (for buys) or above (for sells) the price that triggered the signal limit orders are quoted "+
+        "at. Either a percentage (e.g. 0.1%) or an absolute amount of USD (e.g. 10). Disabled if empty.",
+  )
+
+  cfgOrderTimeout = flag.Duration(
+    "order-timeout",
+    1*time.Minute,
+    "How long an order may go without completely filling before it is re-quoted or cancelled. Mock limit "+
+        "orders measure this in market time.",
+  )
+
+  cfgOrderPoll = flag.Duration(
+    "order-poll",
+    5*time.Second,
+    "How often the status of orders that are in flight should be checked.",
+  )
+
+  cfgOrderRequotes = flag.Int(
+    "order-requotes",
+    0,
+    "How many times a limit order that does not fill before its deadline should be re-quoted at the latest "+
+        "price before it is cancelled.",
+  )
+
+  cfgStopLoss = flag.String(
+    "stop-loss",
+    "",
+    "How far below the entry price a position may fall before it is exited. Either a percentage (e.g. 2%) or "+
+        "an absolute amount of USD (e.g. 150). Disabled if empty.",
+  )
+
+  cfgTakeProfit = flag.String(
+    "take-profit",
+    "",
+    "How far above the entry price a position may rise before it is exited. Either a percentage (e.g. 5%) or "+
+        "an absolute amount of USD (e.g. 400). Disabled if empty.",
+  )
+
+  cfgTrailingStop = flag.String(
+    "trailing-stop",
+    "",
+    "How far below the highest price seen since entry a position may fall before it is exited. Either a "+
+        "percentage (e.g. 3%) or an absolute amount of USD (e.g. 200). Disabled if empty.",
+  )
+
+  cfgSizing = flag.String(
+    "sizing",
+    "all",
+    "How much USD should be spent on entering each position. Valid values are all (split the free balance "+
+        "evenly between waiting markets), fixed, fraction (of equity), atr (volatility-targeted), and kelly.",
+  )
+
+  cfgSizingAmt = flag.Float64(
+    "sizing-amount",
+    100,
+    "The amount of USD to spend on each position when using fixed sizing.",
+  )
+
+  cfgSizingFraction = flag.Float64(
+    "sizing-fraction",
+    0.1,
+    "The fraction of equity to spend on each position when using fraction sizing, or until enough trades have "+
+        "been closed out when using kelly sizing.",
+  )
+
+  cfgSizingRisk = flag.Float64(
+    "sizing-risk",
+    0.01,
+    "The fraction of equity to risk losing on a move of the ATR multiple against each position when using atr "+
+        "sizing.",
+  )
+
+  cfgSizingATRPeriod = flag.Int(
+    "sizing-atr-period",
+    14,
+    "The length (in candles) of the average true range that is used by atr sizing. Strategies that hint at "+
+        "their own average true range take precedence.",
+  )
+
+  cfgSizingATRMult = flag.Float64(
+    "sizing-atr-multiple",
+    2,
+    "The multiple of the average true range that risk is measured against when using atr sizing.",
+  )
+
+  cfgSizingATRIntvl = flag.Duration(
+    "sizing-atr-interval",
+    1*time.Hour,
+    "The interval of candles that the average true range used by atr sizing is calculated from.",
+  )
+
+  cfgSizingKellyScl = flag.Float64(
+    "sizing-kelly-scale",
+    0.5,
+    "How much of the full Kelly fraction to spend on each position when using kelly sizing (e.g. 0.5 for half "+
+        "Kelly).",
+  )
+
+  cfgSizingKellyMin = flag.Int(
+    "sizing-kelly-min-trades",
+    10,
+    "How many trades must be closed out before kelly sizing stops falling back to fraction sizing.",
+  )
+
+  cfgSizingMax = flag.String(
+    "sizing-max",
+    "",
+    "The most USD that may be spent on a single position. Either a single amount for every market (e.g. 500), "+
+        "amounts for specific markets (e.g. BTC:500,ETH:200), or both. Uncapped if empty.",
+  )
+
+  cfgMockSlippage = flag.String(
+    "mock-slippage",
+    "",
+    "How far the price of every mock fill moves against the order. Either a percentage (e.g. 0.05%) or an "+
+        "absolute amount of USD (e.g. 5). Disabled if empty.",
+  )
+
+  cfgMockSpread = flag.Float64(
+    "mock-spread",
+    0,
+    "The fraction of each candle's high/low range that mock fills pay as the bid/ask spread (half on each "+
+        "side). Disabled if 0.",
+  )
+
+  cfgMockLatency = flag.Duration(
+    "mock-latency",
+    0,
+    "How long it takes mock orders to reach the market. If positive, mock orders are filled at the open of the "+
+        "next candle that closes after they arrive rather than at the price that triggered them.",
+  )
+
+  cfgMockParticipation = flag.Float64(
+    "mock-participation",
+    0,
+    "The largest fraction of each candle's volume that a mock order may fill. Whatever remains is filled over "+
+        "the following candles. Uncapped if 0.",
+  )
+
+  cfgLiveFee = flag.Float64(
+    "live-fee",
+    0.00075,
+    "The maker/taker fee that each live fill is estimated to cost when it is recorded in the ledger.",
+  )
+
+  cfgLiveMakerFee = flag.Float64(
+    "live-maker-fee",
+    -1,
+    "The fee that each live fill which rested on the order book is estimated to cost. Defaults to -live-fee "+
+        "if negative.",
+  )
+
+  cfgLiveTakerFee = flag.Float64(
+    "live-taker-fee",
+    -1,
+    "The fee that each live fill which took from the order book is estimated to cost. Defaults to -live-fee "+
+        "if negative.",
+  )
+
+  cfgLedgerDir = flag.String(
+    "ledger-dir",
+    "",
+    "The directory that the ledger of every fill (fills.csv) and every completed trade (trades.csv) should be "+
+        "exported to. Fills are written out as they happen, while trades are written out at shutdown. Disabled "+
+        "if empty.",
+  )
 }
 
 //
@@ -35,15 +261,48 @@ type Service struct {
   mu        *sync.Mutex
   chKill    chan bool
   chStopped chan bool
-  asset     string
-  position  position
+  assets    []string           // The assets being traded, in the order that they were configured.
+  markets   map[string]*market // The market being traded for each asset.
+  paused    bool               // Whether or not new positions are currently prohibited from being entered.
+
+  client       exchange.Client
+  qtyPrecision int32                // The number of decimals that order quantities of assets should be truncated to.
+  usd          decimal.Decimal      // The most recently-reconciled free USD balance of the real account.
+  orderType    exchange.OrderType   // The type of order to place.
+  timeInForce  exchange.TimeInForce // How long limit orders remain active.
+  limitOffset  *Threshold           // How far away from the triggering price limit orders are quoted at.
+  orderTimeout time.Duration        // How long an order may go without completely filling before it is re-quoted or cancelled.
+  orderPoll    time.Duration        // How often the status of orders that are in flight should be checked.
+  maxRequotes  int                  // How many times a limit order may be re-quoted before it is cancelled.
+
+  stopLoss     *Threshold // How far below the entry price a position may fall before it is exited.
+  takeProfit   *Threshold // How far above the entry price a position may rise before it is exited.
+  trailingStop *Threshold // How far below the highest price since entry a position may fall before it is exited.
+
+  sizer       Sizer         // Decides how much USD should be spent on entering each position.
+  sizingCaps  *sizingCaps   // Limits how much USD may be spent on a single position.
+  atrPeriod   int           // The length (in candles) of the average true range of each market.
+  atrInterval time.Duration // The interval of candles that the average true range of each market is calculated from.
+
+  reconcileSeq  int // The number of reconciliations against account balances that have been started.
+  reconciledSeq int // The most recently-started reconciliation whose balances have been applied.
+
+  clock      time.Time   // The most recent timestamp that the Broker Service has been told about (i.e. market time).
+  ledger     *Ledger     // The record of every fill.
+  ledgerDir  string      // The directory that the ledger is exported to, if any.
+  ledgerFile *os.File    // The file that fills are journaled to as they happen, if any.
+  tradeTimes []time.Time // When each recent trade was executed (in market time).
 
   isMockTrading bool
-  mockTradeFee  decimal.Decimal
+  mockMakerFee  decimal.Decimal // The fee that mock fills which rested on the order book cost.
+  mockTakerFee  decimal.Decimal // The fee that mock fills which took liquidity from the order book cost.
+  liveMakerFee  decimal.Decimal // The fee that live fills which rested on the order book are estimated to cost.
+  liveTakerFee  decimal.Decimal // The fee that live fills which took liquidity from the order book are estimated to cost.
   mockUSD       decimal.Decimal
   mockUSDInit   decimal.Decimal
   mockUSDGain   decimal.Decimal
-  mockBTC       decimal.Decimal
+  mockFills     *fillModel // Describes how the mock trade executor fills orders.
+  mockOrderCnt  int        // The number of orders that the mock trade executor has placed.
 }
 
 //
@@ -53,9 +312,103 @@ func Instance() *Service {
   once.Do(func() {
     o = &Service{
       mu:            &sync.Mutex{},
-      position:      offline,
+      markets:       make(map[string]*market),
+      qtyPrecision:  8,
+      orderType:     exchange.Market,
+      orderTimeout:  *cfgOrderTimeout,
+      orderPoll:     *cfgOrderPoll,
+      maxRequotes:   *cfgOrderRequotes,
+      ledger:        NewLedger(),
+      ledgerDir:     *cfgLedgerDir,
       isMockTrading: false,
     }
+
+    switch *cfgOrderType {
+    case "market":
+      o.orderType = exchange.Market
+    case "limit":
+      o.orderType = exchange.Limit
+    default:
+      logger.Fatalf("Failed to instantiate. Unknown order type %s. Valid values are market and limit.", *cfgOrderType)
+    }
+
+    switch *cfgOrderTIF {
+    case "GTC":
+      o.timeInForce = exchange.GoodTillCanceled
+    case "IOC":
+      o.timeInForce = exchange.ImmediateOrCancel
+    case "FOK":
+      o.timeInForce = exchange.FillOrKill
+    default:
+      logger.Fatalf("Failed to instantiate. Unknown time in force %s. Valid values are GTC, IOC, and FOK.", *cfgOrderTIF)
+    }
+
+    o.liveMakerFee = decimal.NewFromFloat(*cfgLiveFee)
+    o.liveTakerFee = decimal.NewFromFloat(*cfgLiveFee)
+
+    if *cfgLiveMakerFee >= 0 {
+      o.liveMakerFee = decimal.NewFromFloat(*cfgLiveMakerFee)
+    }
+
+    if *cfgLiveTakerFee >= 0 {
+      o.liveTakerFee = decimal.NewFromFloat(*cfgLiveTakerFee)
+    }
+
+    var err error
+
+    if o.limitOffset, err = ParseThreshold(*cfgOrderOffset); err != nil {
+      logger.Fatalf("Failed to instantiate. Limit order offset could not be parsed. (Error: %s)", err)
+    }
+
+    if o.stopLoss, err = ParseThreshold(*cfgStopLoss); err != nil {
+      logger.Fatalf("Failed to instantiate. Stop-loss could not be parsed. (Error: %s)", err)
+    }
+
+    if o.takeProfit, err = ParseThreshold(*cfgTakeProfit); err != nil {
+      logger.Fatalf("Failed to instantiate. Take-profit could not be parsed. (Error: %s)", err)
+    }
+
+    if o.trailingStop, err = ParseThreshold(*cfgTrailingStop); err != nil {
+      logger.Fatalf("Failed to instantiate. Trailing stop could not be parsed. (Error: %s)", err)
+    }
+
+    o.sizer, err = newSizer(*cfgSizing)
+    if err != nil {
+      logger.Fatalf("Failed to instantiate. (Error: %s)", err)
+    }
+
+    if o.sizingCaps, err = parseSizingCaps(*cfgSizingMax); err != nil {
+      logger.Fatalf("Failed to instantiate. Position size caps could not be parsed. (Error: %s)", err)
+    }
+
+    o.atrPeriod = *cfgSizingATRPeriod
+    o.atrInterval = *cfgSizingATRIntvl
+
+    if _, err := indicators.NewATR(o.atrPeriod, indicators.Unrounded); err != nil {
+      logger.Fatalf("Failed to instantiate. (Error: %s)", err)
+    }
+
+    o.mockFills = &fillModel{
+      spread:        decimal.NewFromFloat(*cfgMockSpread),
+      latency:       *cfgMockLatency,
+      participation: decimal.NewFromFloat(*cfgMockParticipation),
+    }
+
+    if o.mockFills.slippage, err = ParseThreshold(*cfgMockSlippage); err != nil {
+      logger.Fatalf("Failed to instantiate. Mock slippage could not be parsed. (Error: %s)", err)
+    }
+
+    if o.mockFills.spread.LessThan(decimal.Zero) || o.mockFills.latency < 0 ||
+        o.mockFills.participation.LessThan(decimal.Zero) {
+      logger.Fatalf("Failed to instantiate. Mock spread, latency, and participation must not be negative.")
+    }
+
+    if o.stopLoss != nil || o.takeProfit != nil || o.trailingStop != nil {
+      logger.Printf(
+        "Enabled protective exits. (Stop-Loss: %s, Take-Profit: %s, Trailing Stop: %s)",
+        o.stopLoss, o.takeProfit, o.trailingStop,
+      )
+    }
   })
 
   return o
@@ -63,27 +416,119 @@ func Instance() *Service {
 
 //
 // EnableMockTrading turns on the mock trade executor and funds it with the provided initial amount
-// of capital.
+// of capital. Fills that rest on the order book (i.e. limit orders that were not immediately
+// marketable) cost the maker fee, while all others cost the taker fee.
 //
-func (o *Service) EnableMockTrading(initUSDHolding decimal.Decimal, tradeFee decimal.Decimal) {
+func (o *Service) EnableMockTrading(initUSDHolding decimal.Decimal, makerFee decimal.Decimal, takerFee decimal.Decimal) {
   o.mu.Lock()
   defer o.mu.Unlock()
 
   o.mockUSD = initUSDHolding
   o.mockUSDInit = initUSDHolding
-  o.mockTradeFee = tradeFee
+  o.mockMakerFee = makerFee
+  o.mockTakerFee = takerFee
   o.mockUSDGain = decimal.Zero
   o.isMockTrading = true
 
-  logger.Printf("Enabled mock trading. (Initial USD: %s, Trade Fee: %s)", initUSDHolding, tradeFee)
+  logger.Printf(
+    "Enabled mock trading. (Initial USD: %s, Maker Fee: %s, Taker Fee: %s, Order Type: %s, Time In Force: %s)",
+    initUSDHolding, makerFee, takerFee, o.orderType, o.timeInForce,
+  )
+
+  if o.mockFills.realistic() {
+    logger.Printf(
+      "Enabled mock fill model. (Slippage: %s, Spread: %s, Latency: %s, Participation: %s)",
+      o.mockFills.slippage, o.mockFills.spread, o.mockFills.latency, o.mockFills.participation,
+    )
+  }
+}
+
+//
+// SetAssets tells the Broker Service which assets it should be trading. Each asset is traded in its
+// own market (against USD) with its own position, but all of them share the same USD balance. These
+// should normally be the same assets that are being monitored by the Monitor Service.
+//
+func (o *Service) SetAssets(assets []string) {
+  o.mu.Lock()
+  defer o.mu.Unlock()
+
+  o.assets = append([]string{}, assets...)
+  o.markets = make(map[string]*market)
+
+  for _, asset := range assets {
+    symbol := ""
+
+    if o.client != nil {
+      symbol = o.client.RetrieveSymbol(asset, QuoteAsset)
+    }
+
+    o.markets[asset] = newMarket(asset, symbol, o.atrPeriod)
+  }
+}
+
+//
+// SetSizer tells the Broker Service how it should decide how much USD to spend on entering each
+// position, overriding the sizing policy selected by the command line flags.
+//
+func (o *Service) SetSizer(sizer Sizer) {
+  o.mu.Lock()
+  defer o.mu.Unlock()
+
+  o.sizer = sizer
+}
+
+//
+// ATRInterval returns the interval of candles that the Broker Service expects to be provided with
+// (via ObserveCandle) in order to track the average true range of each market.
+//
+func (o *Service) ATRInterval() time.Duration {
+  return o.atrInterval
+}
+
+//
+// ObserveCandle provides the Broker Service with a newly-closed candle of the specified asset's
+// market so that it can keep track of the market's average true range. The market is also marked
+// to the candle's close so that equity is never valued against an older price.
+//
+func (o *Service) ObserveCandle(asset string, newCandle *candle.Candle) {
+  o.mu.Lock()
+  defer o.mu.Unlock()
+
+  if market, ok := o.markets[asset]; ok {
+    market.atr.Add(newCandle.HighAmt(), newCandle.LowAmt(), newCandle.CloseAmt())
+    market.lastPrice = newCandle.CloseAmt()
+  }
+}
+
+//
+// Hint provides the Broker Service with a strategy's advice about how new positions in the
+// specified asset's market should be sized. The most recent hint for each market is used until
+// another one replaces it.
+//
+func (o *Service) Hint(asset string, hint SizeHint) {
+  o.mu.Lock()
+  defer o.mu.Unlock()
+
+  if market, ok := o.markets[asset]; ok {
+    market.hint = hint
+  }
+}
+
+//
+// SetClient tells the Broker Service which client instance it should use to communicate with the
+// relevant exchange's REST API (e.g. for placing orders and checking balances). This should be
+// called before the asset is set.
+//
+func (o *Service) SetClient(client exchange.Client) {
+  o.client = client
 }
 
 //
-// SetAsset tells the Broker Service which asset it should be trading. This should normally be the
-// same asset that is being monitored by the Monitor Service.
+// SetQuantityPrecision tells the Broker Service how many decimals order quantities of the asset
+// should be truncated to. This must not be finer than the exchange's step size for the market.
 //
-func (o *Service) SetAsset(asset string) {
-  o.asset = asset
+func (o *Service) SetQuantityPrecision(precision int32) {
+  o.qtyPrecision = precision
 }
 
 //
@@ -96,6 +541,41 @@ func (o *Service) DisableMockTrading() {
   o.isMockTrading = false
 }
 
+//
+// Pause tells the Broker Service to stop entering new positions (e.g. because the market data that
+// signals are being derived from can no longer be trusted). Positions that are already held can
+// still be exited.
+//
+func (o *Service) Pause(reason string) {
+  o.mu.Lock()
+  defer o.mu.Unlock()
+
+  if o.paused {
+    return
+  }
+
+  o.paused = true
+
+  logger.Printf("Paused new entries. (Reason: %s)", reason)
+}
+
+//
+// Resume tells the Broker Service that it may begin entering new positions again after having been
+// paused.
+//
+func (o *Service) Resume() {
+  o.mu.Lock()
+  defer o.mu.Unlock()
+
+  if !o.paused {
+    return
+  }
+
+  o.paused = false
+
+  logger.Printf("Resumed new entries.")
+}
+
 //
 // Start implements the Service interface's described method.
 //
@@ -106,7 +586,17 @@ func (o *Service) Start() (<-chan bool, error) {
   //
   // Validate that necessary configurations have been provided.
   //
-  // TODO ~> This.
+  if !o.isMockTrading && o.client == nil {
+    return nil, errors.New("a client must be provided when mock trading is disabled")
+  }
+
+  if len(o.markets) == 0 {
+    return nil, errors.New("at least one asset must be provided to trade")
+  }
+
+  if o.orderTimeout <= 0 || o.orderPoll <= 0 {
+    return nil, errors.New("the order timeout and poll interval must be positive")
+  }
 
   //
   // (Re)initialize our instance variables.
@@ -115,9 +605,41 @@ func (o *Service) Start() (<-chan bool, error) {
   o.chStopped = make(chan bool, 1)
 
   //
-  // Adjust the tracked position (a.k.a. state) of the service to indicate that it is now running.
+  // Adjust the tracked position (a.k.a. state) of each market to indicate that the service is now
+  // running. If we are trading for real, the positions must be reconciled against what the account
+  // actually holds.
+  //
+  for _, market := range o.markets {
+    market.position = waiting
+  }
+
+  if !o.isMockTrading {
+    if err := o.reconcile(); err != nil {
+      return nil, err
+    }
+  }
+
+  //
+  // Begin journaling fills to the ledger's export directory (if there is one).
+  //
+  if o.ledgerDir != "" {
+    var err error
+
+    if o.ledgerFile, err = os.Create(filepath.Join(o.ledgerDir, "fills.csv")); err != nil {
+      return nil, err
+    }
+
+    if err := o.ledger.Journal(o.ledgerFile); err != nil {
+      return nil, err
+    }
+
+    logger.Printf("Journaling fills to %s.", o.ledgerFile.Name())
+  }
+
+  //
+  // Fire off a goroutine as the executor for the service.
   //
-  o.position = waiting
+  go o.service()
 
   //
   // Return our "started" channel in case the caller wants to block on it and log some debug info.
@@ -148,15 +670,39 @@ func (o *Service) Stop() (<-chan bool, error) {
   o.chKill <- true
 
   //
-  // Adjust the tracked position (a.k.a. state) of the service to indicate that it is no longer
-  // running.
+  // Adjust the tracked position (a.k.a. state) of each market to indicate that the service is no
+  // longer running.
+  //
+  for _, market := range o.markets {
+    market.position = offline
+  }
+
+  //
+  // Warn about any sells that the ledger could not account for, as they are missing from realized
+  // PnL and completed trades.
   //
-  o.position = offline
+  if unmatched := o.ledger.Unmatched(""); len(unmatched) > 0 {
+    logger.Printf(
+      "%d sells (or parts of sells) had no open position in the ledger to be matched against and are not "+
+          "included in realized PnL or completed trades.",
+      len(unmatched),
+    )
+  }
 
   //
-  // Send the signal that we have shut down.
+  // Export the completed trades alongside the journaled fills.
   //
-  o.chStopped <- true
+  if o.ledgerFile != nil {
+    if err := o.exportTrades(); err != nil {
+      logger.Printf("Failed to export completed trades. (Error: %s)", err)
+    }
+
+    if err := o.ledgerFile.Close(); err != nil {
+      logger.Printf("Failed to close handle on ledger file. (Error: %s)", err)
+    }
+
+    o.ledgerFile = nil
+  }
 
   //
   // Return the "stopped" channel that the caller can block on if they need to know that the
@@ -166,87 +712,1122 @@ func (o *Service) Stop() (<-chan bool, error) {
 }
 
 //
-// Signal tells the Broker Service that a trend or scenario has been detected by an algorithm so
-// it can decide if it wants to enter or exit a position.
+// service periodically checks up on any orders that are in flight until the service is told to
+// shut down.
+//
+func (o *Service) service() {
+  ticker := time.NewTicker(o.orderPoll)
+  defer ticker.Stop()
+
+  for {
+    select {
+    case <-o.chKill:
+      o.chStopped <- true
+
+      return
+
+    case <-ticker.C:
+      o.pollOrders()
+    }
+  }
+}
+
+//
+// Signal tells the Broker Service that a trend or scenario has been detected by an algorithm (the
+// source, e.g. the name of a strategy) in the specified asset's market so it can decide if it wants
+// to enter or exit a position.
+//
+func (o *Service) Signal(asset string, source string, signal Signal, price decimal.Decimal, timestamp time.Time) {
+  o.SignalCapped(asset, source, signal, price, timestamp, nil)
+}
+
+//
+// SignalCapped is the same as Signal, except that if a new position is entered, no more than the
+// provided amount of USD (if any) will be spent on it. This allows something sitting in front of
+// the Broker Service (e.g. a risk manager) to limit its exposure to any one asset.
 //
-func (o *Service) Signal(signal Signal, price decimal.Decimal, timestamp time.Time) {
+func (o *Service) SignalCapped(asset string, source string, signal Signal, price decimal.Decimal, timestamp time.Time, maxSpend *decimal.Decimal) {
   o.mu.Lock()
   defer o.mu.Unlock()
 
-  // TODO ~> Flesh this mechanism out quite a bit. For now, we just immediately pretend to execute
-  //  a trade so that we can see how we are doing.
-
   //
-  // Depending on the signal that came in, enter or exit a position.
+  // Make sure that we are actually trading the asset.
   //
-  gainMsg := ""
-  feeMsg := ""
+  market, ok := o.markets[asset]
+  if !ok {
+    logger.Printf("Ignoring signal (at %s) for %s because it is not being traded.", price, asset)
 
-  if signal == UptrendDetected && o.position == waiting {
-    //
-    // Calculate the transaction fee.
-    //
-    fee := o.mockUSD.Mul(o.mockTradeFee)
+    return
+  }
 
-    //
-    // Execute the mock transaction and enter the new position.
-    //
-    o.mockBTC = o.mockUSD.Sub(fee).Div(price)
-    o.mockUSD = decimal.Zero
-    o.position = holding
+  o.signal(market, source, signal, price, timestamp, maxSpend)
+}
 
-    //
-    // Build out a message explaining how much was spent on transaction fees during this mock trade.
-    //
-    feeMsg = fmt.Sprintf("Fees were %s.", aurora.Bold(aurora.Blue(fmt.Sprintf("%s USD", fee))))
-  } else if signal == DowntrendDetected && o.position == holding {
-    //
-    // Calculate the transaction fee.
-    //
-    fee := o.mockBTC.Mul(o.mockTradeFee)
+//
+// signal acts upon a signal in the provided market, spending no more than the provided amount of
+// USD (if any) on entering a new position. The caller must hold the service's lock, although it is
+// released while any requests are made to the exchange (see call).
+//
+func (o *Service) signal(market *market, source string, signal Signal, price decimal.Decimal, timestamp time.Time, maxSpend *decimal.Decimal) {
+  asset := market.asset
+  market.lastPrice = price
+  o.tick(timestamp)
 
-    //
-    // Execute the mock transaction and exit the current position.
-    //
-    o.mockUSD = o.mockBTC.Mul(price).Sub(fee)
-    o.mockBTC = decimal.Zero
-    o.position = waiting
-    o.mockUSDGain = o.mockUSD.Sub(o.mockUSDInit)
+  //
+  // Ignore signals to enter new positions while we are paused.
+  //
+  if o.paused && signal == UptrendDetected {
+    logger.Printf("Ignoring %s entry signal (at %s) because new entries are paused.", asset, price)
 
-    //
-    // Build out a message explaining how much was spent on transaction fees during this mock trade.
-    //
-    feeMsg = fmt.Sprintf("Fees were %s.", aurora.Bold(aurora.Blue(fmt.Sprintf("%s %s", fee, o.asset))))
+    return
+  }
 
-    //
-    // Since we are now holding USD again, build out a message that explains the current running
-    // USD gain/loss. Also, notify the Writer Service so that it can track the data point if it
-    // cares.
-    //
-    if o.mockUSDGain.GreaterThan(decimal.Zero) {
-      gainMsg = fmt.Sprintf(
-        "Total running gain/loss is %s.",
-        aurora.Bold(aurora.Green(fmt.Sprintf("%s USD", o.mockUSDGain))),
-      )
-    } else {
-      gainMsg = fmt.Sprintf(
-        "Total running gain/loss is %s.",
-        aurora.Bold(aurora.Red(fmt.Sprintf("%s USD", o.mockUSDGain))),
-      )
+  //
+  // If we are trading for real, hand off to the live trade executor. If the market is busy with a
+  // request to the exchange, the signal is acted upon once the request is done instead.
+  //
+  if !o.isMockTrading {
+    if market.busy {
+      logger.Printf("Deferring %s signal (at %s) until its outstanding order request is done.", asset, price)
+
+      market.deferred = &deferredSignal{source, signal, price, timestamp, maxSpend}
+
+      return
     }
 
-    _ = writer.Instance().Write(timestamp, writer.GrossMockEarnings, o.mockUSDGain)
+    o.act(market, func() {
+      o.executeLiveTrade(market, source, signal, price, maxSpend)
+    })
+
+    return
   }
 
-  //
-  // Log details about the current position now that the mock trade has been executed.
-  //
-  logger.Printf(
-    "Mock trade executed (at %s)! Current holdings are %s and %s. %s %s",
-    price,
-    aurora.Bold(aurora.Yellow(fmt.Sprintf("%s %s", o.mockBTC, o.asset))),
-    aurora.Bold(aurora.Green(fmt.Sprintf("%s USD", o.mockUSD))),
-    feeMsg,
-    gainMsg,
-  )
-}
\ No newline at end of file
+  o.executeMockTrade(market, source, signal, price, timestamp, maxSpend)
+}
+
+//
+// Mark tells the Broker Service about a trade that has occurred in the specified asset's market so
+// that it can evaluate protective exits (e.g. stop-losses) against it.
+//
+func (o *Service) Mark(asset string, price decimal.Decimal, timestamp time.Time) {
+  o.MarkRange(asset, price, price, price, timestamp)
+}
+
+//
+// MarkRange tells the Broker Service about the range of prices that the specified asset's market
+// traded within over a period of time (e.g. the high, low, and close of a candle) so that it can
+// evaluate protective exits against it.
+//
+func (o *Service) MarkRange(asset string, high decimal.Decimal, low decimal.Decimal, last decimal.Decimal, timestamp time.Time) {
+  o.mu.Lock()
+  defer o.mu.Unlock()
+
+  if market, ok := o.markets[asset]; ok {
+    o.mark(market, bar{open: last, high: high, low: low, close: last}, timestamp)
+  }
+}
+
+//
+// MarkCandle tells the Broker Service about a candle that has closed out in the specified asset's
+// market. This is the same as MarkRange, except that the candle's open and volume are also taken
+// into account by the mock trade executor when filling orders.
+//
+func (o *Service) MarkCandle(asset string, exCandle exchange.Candle) {
+  o.mu.Lock()
+  defer o.mu.Unlock()
+
+  if market, ok := o.markets[asset]; ok {
+    o.mark(market, bar{
+      open:   *exCandle.Open(),
+      high:   *exCandle.High(),
+      low:    *exCandle.Low(),
+      close:  *exCandle.Close(),
+      volume: exCandle.Volume(),
+    }, *exCandle.EndTime())
+  }
+}
+
+//
+// mark moves the provided market along to the provided bar, filling any mock order that is in
+// flight and evaluating protective exits against it. The caller must hold the service's lock.
+//
+func (o *Service) mark(market *market, latest bar, timestamp time.Time) {
+  market.lastPrice = latest.close
+  market.bar = latest
+  o.tick(timestamp)
+
+  //
+  // Give any mock order that has reached the market a chance to fill.
+  //
+  if o.isMockTrading && market.inFlight() && timestamp.After(market.order.arrives) {
+    o.fillMockOrder(market, latest.open, latest, true, timestamp)
+  }
+
+  if o.isMockTrading && market.inFlight() && !market.order.deadline.IsZero() && timestamp.After(market.order.deadline) {
+    o.expireMockOrder(market, timestamp)
+  }
+
+  high := latest.high
+  low := latest.low
+  last := latest.close
+
+  //
+  // Protective exits only apply to positions that are being held.
+  //
+  if market.position != holding || market.inFlight() {
+    return
+  }
+
+  if market.entryPrice.IsZero() {
+    market.enter(last)
+  }
+
+  //
+  // Determine if any exit has been triggered. Losses are checked before gains, as we cannot know
+  // the order in which prices occurred within the range and it is safest to assume the worst.
+  //
+  // NOTE ~> Exits are filled at the price that triggered them unless the whole range is beyond
+  //  that price (i.e. the price gapped through it), in which case they are filled at the nearest
+  //  price within the range.
+  //
+  reason, trigger := o.triggeredExit(market, high, low)
+  if reason == "" {
+    market.peakPrice = decimal.Max(market.peakPrice, high)
+
+    return
+  }
+
+  price := trigger
+
+  if high.LessThan(trigger) {
+    price = high
+  } else if low.GreaterThan(trigger) {
+    price = low
+  }
+
+  logger.Printf(
+    "%s %s triggered at %s (Entry: %s, Peak: %s). Exiting position.",
+    market.asset, reason, trigger, market.entryPrice, market.peakPrice,
+  )
+
+  o.signal(market, reason, DowntrendDetected, price, timestamp, nil)
+}
+
+//
+// triggeredExit determines whether or not the provided range of prices triggers any of the
+// protective exits of the provided market's position. If one does, a description of it and the
+// price that triggered it are returned.
+//
+func (o *Service) triggeredExit(market *market, high decimal.Decimal, low decimal.Decimal) (string, decimal.Decimal) {
+  if o.stopLoss != nil {
+    if trigger := o.stopLoss.Below(market.entryPrice); !low.GreaterThan(trigger) {
+      return "Stop-loss", trigger
+    }
+  }
+
+  if o.trailingStop != nil {
+    if trigger := o.trailingStop.Below(market.peakPrice); !low.GreaterThan(trigger) {
+      return "Trailing stop", trigger
+    }
+  }
+
+  if o.takeProfit != nil {
+    if trigger := o.takeProfit.Above(market.entryPrice); !high.LessThan(trigger) {
+      return "Take-profit", trigger
+    }
+  }
+
+  return "", decimal.Zero
+}
+
+//
+// Equity returns the total value (in USD) of everything that is held, marking each held asset to
+// the most recent price that the Broker Service has been told about.
+//
+func (o *Service) Equity() decimal.Decimal {
+  o.mu.Lock()
+  defer o.mu.Unlock()
+
+  return o.equity()
+}
+
+//
+// Exposure returns the value (in USD) of the specified asset that is held, marked to the most
+// recent price that the Broker Service has been told about.
+//
+func (o *Service) Exposure(asset string) decimal.Decimal {
+  o.mu.Lock()
+  defer o.mu.Unlock()
+
+  market, ok := o.markets[asset]
+  if !ok {
+    return decimal.Zero
+  }
+
+  if o.isMockTrading {
+    return market.mockHeld.Mul(market.lastPrice)
+  }
+
+  return market.held.Mul(market.lastPrice)
+}
+
+//
+// Clock returns the most recent timestamp that the Broker Service has been told about. This is
+// market time rather than wall time, so it is also meaningful during backtests.
+//
+func (o *Service) Clock() time.Time {
+  o.mu.Lock()
+  defer o.mu.Unlock()
+
+  return o.clock
+}
+
+//
+// RecentTrades returns how many trades have been executed within the provided window of time
+// leading up to the Broker Service's clock.
+//
+func (o *Service) RecentTrades(window time.Duration) int {
+  o.mu.Lock()
+  defer o.mu.Unlock()
+
+  cnt := 0
+  since := o.clock.Add(-window)
+
+  for _, t := range o.tradeTimes {
+    if t.After(since) {
+      cnt++
+    }
+  }
+
+  return cnt
+}
+
+//
+// Flatten exits every position that is held (e.g. because a risk limit has been tripped).
+//
+func (o *Service) Flatten(reason string) {
+  o.mu.Lock()
+  defer o.mu.Unlock()
+
+  logger.Printf("Flattening all positions. (Reason: %s)", reason)
+
+  for _, asset := range o.assets {
+    market := o.markets[asset]
+
+    if market.position == holding || market.position == buying {
+      o.signal(market, "Flatten", DowntrendDetected, market.lastPrice, o.clock, nil)
+    }
+  }
+}
+
+//
+// Ledger returns the record of every fill that the Broker Service has made.
+//
+func (o *Service) Ledger() *Ledger {
+  return o.ledger
+}
+
+//
+// UnrealizedPnL returns the profit (or loss) in USD of the positions that are still held, marking
+// each to the most recent price that the Broker Service has been told about.
+//
+func (o *Service) UnrealizedPnL() decimal.Decimal {
+  o.mu.Lock()
+  defer o.mu.Unlock()
+
+  marks := make(map[string]decimal.Decimal)
+
+  for asset, market := range o.markets {
+    marks[asset] = market.lastPrice
+  }
+
+  return o.ledger.UnrealizedPnL(marks)
+}
+
+//
+// Cancel cancels the order that is in flight in the specified asset's market (e.g. a resting limit
+// order), keeping whatever was filled before the cancellation. Returns whether or not an order was
+// cancelled.
+//
+func (o *Service) Cancel(asset string) bool {
+  o.mu.Lock()
+  defer o.mu.Unlock()
+
+  market, ok := o.markets[asset]
+  if !ok || !market.inFlight() {
+    return false
+  }
+
+  logger.Printf("Cancelling %s order %s on request.", market.asset, market.order.id)
+
+  if o.isMockTrading {
+    o.finishMockOrder(market, cancelled, o.clock)
+
+    return true
+  }
+
+  if market.busy {
+    logger.Printf("Cannot cancel %s order %s while another request for it is outstanding.", market.asset, market.order.id)
+
+    return false
+  }
+
+  done := false
+
+  o.act(market, func() {
+    done = o.cancelOrder(market, cancelled)
+  })
+
+  return done
+}
+
+//
+// journal records whatever part of the provided order in the provided market has been filled since
+// it was last journaled in the ledger. The provided fee (in USD) is attributed to that part.
+//
+func (o *Service) journal(market *market, order *order, fee decimal.Decimal, timestamp time.Time) {
+  qty := order.filledQty.Sub(order.journaledQty)
+  if !qty.GreaterThan(decimal.Zero) {
+    return
+  }
+
+  price := order.price
+
+  if quote := order.filledQuote.Sub(order.journaledQuote); quote.GreaterThan(decimal.Zero) {
+    price = quote.Div(qty)
+  }
+
+  order.journaledQty = order.filledQty
+  order.journaledQuote = order.filledQuote
+
+  err := o.ledger.Record(Fill{
+    Time:     timestamp,
+    Asset:    market.asset,
+    OrderID:  order.id,
+    Side:     order.side,
+    Price:    price,
+    Qty:      qty,
+    Fee:      fee,
+    Strategy: order.source,
+    Signal:   order.signal,
+  })
+  if err != nil {
+    logger.Printf("Failed to journal %s fill of order %s. (Error: %s)", market.asset, order.id, err)
+  }
+}
+
+//
+// journalLive records whatever part of the provided live order has been filled since it was last
+// journaled in the ledger. The exchange does not report commissions when orders are checked up on,
+// so the fee is estimated from the maker or taker fee rate depending on whether or not the order had
+// come to rest on the order book.
+//
+func (o *Service) journalLive(market *market, order *order) {
+  feeRate := o.liveTakerFee
+
+  if order.resting {
+    feeRate = o.liveMakerFee
+  }
+
+  //
+  // NOTE ~> Like the ledger, fall back on the quoted price if the exchange did not report how much
+  //  of the quote asset the fill was for.
+  //
+  quote := order.filledQuote.Sub(order.journaledQuote)
+
+  if !quote.GreaterThan(decimal.Zero) {
+    quote = order.filledQty.Sub(order.journaledQty).Mul(order.price)
+  }
+
+  fee := decimal.Zero
+
+  if quote.GreaterThan(decimal.Zero) {
+    fee = quote.Mul(feeRate)
+  }
+
+  order.fees = order.fees.Add(fee)
+
+  o.journal(market, order, fee, time.Now())
+}
+
+//
+// exportTrades writes every completed trade in the ledger out to the ledger's export directory.
+//
+func (o *Service) exportTrades() error {
+  f, err := os.Create(filepath.Join(o.ledgerDir, "trades.csv"))
+  if err != nil {
+    return err
+  }
+
+  if err := o.ledger.WriteTrades(f); err != nil {
+    _ = f.Close()
+
+    return err
+  }
+
+  logger.Printf("Exported completed trades to %s.", f.Name())
+
+  return f.Close()
+}
+
+//
+// tick moves the Broker Service's clock forward to the provided timestamp.
+//
+func (o *Service) tick(timestamp time.Time) {
+  if timestamp.After(o.clock) {
+    o.clock = timestamp
+  }
+}
+
+//
+// recordTradeTime records that a trade has just been executed, forgetting about any trades that
+// were executed more than a day ago.
+//
+func (o *Service) recordTradeTime() {
+  since := o.clock.Add(-constants.OneDay)
+  kept := o.tradeTimes[:0]
+
+  for _, t := range o.tradeTimes {
+    if t.After(since) {
+      kept = append(kept, t)
+    }
+  }
+
+  o.tradeTimes = append(kept, o.clock)
+}
+
+//
+// positionSize determines how much of the provided free USD balance should be spent on entering a
+// new position in the provided market, as decided by the configured sizer and limited by the
+// market's cap and the provided maximum spend (if any).
+//
+func (o *Service) positionSize(market *market, price decimal.Decimal, free decimal.Decimal, maxSpend *decimal.Decimal) decimal.Decimal {
+  waitingCnt := 0
+
+  for _, v := range o.markets {
+    if v.position == waiting {
+      waitingCnt++
+    }
+  }
+
+  spend := o.sizer.Size(SizingInput{
+    Asset:   market.asset,
+    Price:   price,
+    Free:    free,
+    Equity:  o.equity(),
+    Waiting: waitingCnt,
+    ATR:     market.sizingATR(),
+    Stats:   tradeStats(o.ledger.Trades("")),
+  })
+
+  spend = o.sizingCaps.limit(market.asset, spend)
+
+  if maxSpend != nil && spend.GreaterThan(*maxSpend) {
+    spend = *maxSpend
+  }
+
+  if spend.GreaterThan(free) {
+    spend = free
+  }
+
+  if spend.LessThan(decimal.Zero) {
+    spend = decimal.Zero
+  }
+
+  return spend
+}
+
+//
+// equity determines the total value (in USD) of everything that is held.
+//
+func (o *Service) equity() decimal.Decimal {
+  if o.isMockTrading {
+    return o.mockEquity()
+  }
+
+  equity := o.usd
+
+  for _, market := range o.markets {
+    equity = equity.Add(market.held.Mul(market.lastPrice))
+  }
+
+  return equity
+}
+
+//
+// mockEquity determines the total value (in USD) of everything that the mock trade executor holds,
+// marking each held asset to the most recent price that we have been told about.
+//
+// NOTE ~> Every market is marked by every trade and one minute candle that the Monitor Service sees
+//  for it, as well as by every candle that is observed for its average true range. As candles of
+//  different markets are produced one after another, other markets may lag by up to one minute.
+//
+func (o *Service) mockEquity() decimal.Decimal {
+  equity := o.mockUSD
+
+  for _, market := range o.markets {
+    equity = equity.Add(market.mockHeld.Mul(market.lastPrice))
+  }
+
+  return equity
+}
+
+//
+// executeLiveTrade enters or exits a position in the provided market on the real exchange depending
+// on the signal that came in.
+//
+// NOTE ~> If an order is already in flight in the market, a signal in the same direction is ignored
+//  as the order is already acting upon it. A signal in the opposite direction cancels the order,
+//  after which the signal is acted upon against whatever position the partial fill (if any) left us
+//  in.
+//
+func (o *Service) executeLiveTrade(market *market, source string, signal Signal, price decimal.Decimal, maxSpend *decimal.Decimal) {
+  //
+  // Deal with any order that is already in flight.
+  //
+  if market.inFlight() {
+    if (signal == UptrendDetected && market.order.side == exchange.Buy) ||
+        (signal == DowntrendDetected && market.order.side == exchange.Sell) {
+      logger.Printf(
+        "Ignoring %s signal (at %s) because %s order %s is already in flight.",
+        market.asset, price, market.order.side, market.order.id,
+      )
+
+      return
+    }
+
+    if signal != UptrendDetected && signal != DowntrendDetected {
+      return
+    }
+
+    logger.Printf(
+      "Cancelling %s order %s because an opposing %s signal (at %s) came in.",
+      market.order.side, market.order.id, market.asset, price,
+    )
+
+    if !o.cancelOrder(market, cancelled) {
+      return
+    }
+  }
+
+  //
+  // Determine which side of the order book we need to be on and how much of the asset to trade.
+  //
+  // NOTE ~> Market orders are not guaranteed to execute at the provided price, so a buy for the
+  //  entire allocation may be rejected for insufficient funds if the price moves against us.
+  //
+  var side exchange.OrderSide
+  var qty decimal.Decimal
+
+  if signal == UptrendDetected && market.position == waiting {
+    side = exchange.Buy
+    qty = o.positionSize(market, price, o.usd, maxSpend).Div(o.quote(side, price)).Truncate(o.qtyPrecision)
+  } else if signal == DowntrendDetected && market.position == holding {
+    side = exchange.Sell
+    qty = market.held.Truncate(o.qtyPrecision)
+  } else {
+    return
+  }
+
+  o.placeOrder(market, newOrder(side, source, signal, qty, o.quote(side, price), time.Now().Add(o.orderTimeout)))
+}
+
+//
+// quote determines the price that an order on the provided side should be quoted at given the
+// provided triggering price. Limit orders are quoted away from the triggering price by the limit
+// offset (if any) so that they rest on the order book rather than take from it.
+//
+func (o *Service) quote(side exchange.OrderSide, price decimal.Decimal) decimal.Decimal {
+  if o.orderType != exchange.Limit || o.limitOffset == nil {
+    return price
+  }
+
+  if side == exchange.Buy {
+    return o.limitOffset.Below(price)
+  }
+
+  return o.limitOffset.Above(price)
+}
+
+//
+// act performs the provided operation on the provided market's live order (e.g. placing, advancing,
+// or cancelling it), marking the market as busy until it is done. Any signal that comes in for the
+// market while it is busy is acted upon afterwards. The caller must hold the service's lock.
+//
+func (o *Service) act(market *market, operation func()) {
+  market.busy = true
+  operation()
+  market.busy = false
+
+  deferred := market.deferred
+  if deferred == nil {
+    return
+  }
+
+  market.deferred = nil
+
+  if o.paused && deferred.signal == UptrendDetected {
+    logger.Printf("Ignoring deferred %s entry signal (at %s) because new entries are paused.", market.asset, deferred.price)
+
+    return
+  }
+
+  logger.Printf("Acting upon deferred %s signal (at %s).", market.asset, deferred.price)
+
+  o.act(market, func() {
+    o.executeLiveTrade(market, deferred.source, deferred.signal, deferred.price, deferred.maxSpend)
+  })
+}
+
+//
+// call makes the provided request to the exchange without holding the service's lock, so that a
+// slow or hung request does not block marks, protective exits, and signals in the meantime. The
+// caller must hold the lock, and must re-validate anything that it relies upon once call returns,
+// as the service may have changed in the meantime.
+//
+// NOTE ~> The market that the request is for should be busy (see act) so that nothing else acts on
+//  its order while the lock is released.
+//
+func (o *Service) call(request func()) {
+  o.mu.Unlock()
+  defer o.mu.Lock()
+
+  request()
+}
+
+//
+// placeOrder places the provided order on the real exchange and begins tracking it through its
+// lifecycle.
+//
+func (o *Service) placeOrder(market *market, order *order) {
+  if !order.qty.GreaterThan(decimal.Zero) {
+    logger.Printf(
+      "Skipping %s %s order because there is nothing to trade. (Price: %s)", market.asset, order.side, order.price,
+    )
+
+    return
+  }
+
+  //
+  // Begin tracking the order and then place it.
+  //
+  market.order = order
+
+  if order.side == exchange.Buy {
+    market.position = buying
+  } else {
+    market.position = selling
+  }
+
+  var resp exchange.Response
+  var err error
+
+  client, orderType, timeInForce := o.client, o.orderType, o.timeInForce
+  symbol, side, qty, price := market.symbol, order.side, order.qty, order.price
+
+  o.call(func() {
+    if orderType == exchange.Limit {
+      resp, err = client.PlaceLimitOrder(symbol, side, qty, price, timeInForce)
+    } else {
+      resp, err = client.PlaceMarketOrder(symbol, side, qty)
+    }
+  })
+
+  if market.order != order {
+    logger.Printf("Ignoring the response to placing %s %s order because it is no longer being tracked.", market.asset, side)
+
+    return
+  }
+
+  if err != nil {
+    logger.Printf("Failed to place %s order for %s %s. (Error: %s)", order.side, order.qty, market.asset, err)
+
+    o.finishOrder(market, rejected)
+
+    return
+  }
+
+  logger.Printf(
+    "Placed %s %s order for %s %s (at %s).", o.orderType, order.side, order.qty, market.asset, order.price,
+  )
+
+  o.recordTradeTime()
+
+  //
+  // Some orders (e.g. market orders) are filled immediately, so process the order's initial status.
+  //
+  if exOrder := resp.Order(); exOrder != nil {
+    o.advanceOrder(market, exOrder)
+  }
+
+  //
+  // Anything that fills after the order has rested on the order book is charged the maker fee.
+  //
+  if market.order == order && o.orderType == exchange.Limit {
+    order.resting = true
+  }
+}
+
+//
+// poll is a snapshot of an order that was in flight when orders were last checked up on, along with
+// the latest view of it that the exchange provided (if any).
+//
+type poll struct {
+  market  *market
+  order   *order
+  id      string
+  exOrder exchange.Order
+}
+
+//
+// pollOrders checks up on every order that is in flight, moving them through their lifecycles and
+// re-quoting or cancelling any that have passed their deadlines.
+//
+// NOTE ~> The exchange is checked up on without holding the lock (see call). As such, each order is
+//  only moved along if it is still the one in flight once the lock has been re-acquired.
+//
+func (o *Service) pollOrders() {
+  //
+  // Snapshot the orders that are in flight. Mock orders are moved through their lifecycles by market
+  // data instead.
+  //
+  o.mu.Lock()
+  defer o.mu.Unlock()
+
+  if o.isMockTrading {
+    return
+  }
+
+  polls := make([]*poll, 0, len(o.assets))
+
+  for _, asset := range o.assets {
+    market := o.markets[asset]
+
+    if market.inFlight() && !market.busy {
+      polls = append(polls, &poll{market: market, order: market.order, id: market.order.id})
+    }
+  }
+
+  if len(polls) == 0 {
+    return
+  }
+
+  //
+  // Retrieve the latest view of each order from the exchange.
+  //
+  client := o.client
+
+  o.call(func() {
+    for _, p := range polls {
+      resp, err := client.RetrieveOrder(p.market.symbol, p.id)
+      if err != nil {
+        logger.Printf("Failed to check up on %s order %s. (Error: %s)", p.market.asset, p.id, err)
+      } else {
+        p.exOrder = resp.Order()
+      }
+    }
+  })
+
+  now := time.Now()
+
+  for _, p := range polls {
+    market := p.market
+
+    //
+    // Skip any order that was finished, cancelled, or re-quoted (or that is being acted upon) while
+    // the exchange was being checked up on.
+    //
+    if market.busy || market.order != p.order || market.order.id != p.id {
+      continue
+    }
+
+    o.act(market, func() {
+      if p.exOrder != nil {
+        o.advanceOrder(market, p.exOrder)
+      }
+
+      //
+      // If the order still has not filled by its deadline, re-quote it (if allowed) or give up on it.
+      //
+      if market.inFlight() && now.After(market.order.deadline) {
+        o.expireOrder(market)
+      }
+    })
+  }
+}
+
+//
+// advanceOrder moves the order that is in flight in the provided market through its lifecycle
+// given the latest view of it that the exchange has provided.
+//
+func (o *Service) advanceOrder(market *market, exOrder exchange.Order) {
+  order := market.order
+
+  changed, err := order.update(exOrder)
+  if err != nil {
+    logger.Printf("Ignoring unexpected update to %s order. (Error: %s)", market.asset, err)
+
+    return
+  }
+
+  o.journalLive(market, order)
+
+  if changed {
+    logger.Printf(
+      "Live %s order %s is %s! Filled %s of %s %s for %s.",
+      order.side, order.id, order.state,
+      aurora.Bold(aurora.Yellow(order.filledQty.String())), order.qty, market.asset,
+      aurora.Bold(aurora.Green(fmt.Sprintf("%s %s", order.filledQuote, QuoteAsset))),
+    )
+  }
+
+  if order.state.final() {
+    o.finishOrder(market, order.state)
+  }
+}
+
+//
+// expireOrder deals with an order that did not completely fill before its deadline. Limit orders
+// are re-quoted at the latest price for whatever quantity remains unfilled until they run out of
+// re-quotes. All other orders are cancelled.
+//
+func (o *Service) expireOrder(market *market) {
+  order := market.order
+
+  logger.Printf("%s order %s did not fill before its deadline.", order.side, order.id)
+
+  if o.orderType != exchange.Limit || order.requotes >= o.maxRequotes {
+    o.cancelOrder(market, timedOut)
+
+    return
+  }
+
+  //
+  // Cancel the stale order and place a new one at the latest price for the remaining quantity.
+  //
+  remaining := order.remaining().Truncate(o.qtyPrecision)
+
+  if !o.cancelOrder(market, cancelled) {
+    return
+  }
+
+  requote := newOrder(order.side, order.source, order.signal, remaining, o.quote(order.side, market.lastPrice), time.Now().Add(o.orderTimeout))
+  requote.requotes = order.requotes + 1
+
+  logger.Printf(
+    "Re-quoting %s order for %s %s at %s (re-quote %d of %d).",
+    order.side, remaining, market.asset, requote.price, requote.requotes, o.maxRequotes,
+  )
+
+  o.placeOrder(market, requote)
+}
+
+//
+// cancelOrder cancels the order that is in flight in the provided market, finishing it in the
+// provided state. Returns false if the order could not be cancelled (in which case it is still in
+// flight).
+//
+func (o *Service) cancelOrder(market *market, state orderState) bool {
+  order := market.order
+
+  var resp exchange.Response
+  var err error
+
+  client, symbol, id := o.client, market.symbol, order.id
+
+  o.call(func() {
+    resp, err = client.CancelOrder(symbol, id)
+  })
+
+  if market.order != order {
+    logger.Printf("Ignoring the response to cancelling %s order %s because it is no longer being tracked.", market.asset, id)
+
+    return false
+  }
+
+  if err != nil {
+    logger.Printf("Failed to cancel %s order %s. (Error: %s)", market.asset, order.id, err)
+
+    return false
+  }
+
+  //
+  // Capture anything that was filled before the cancellation went through. If the order actually
+  // completely filled in the meantime, it is finished as filled instead.
+  //
+  if exOrder := resp.Order(); exOrder != nil {
+    if _, err := order.update(exOrder); err == nil && order.state == filled {
+      state = filled
+    }
+
+    o.journalLive(market, order)
+  }
+
+  o.finishOrder(market, state)
+
+  return true
+}
+
+//
+// finishOrder moves the order that is in flight in the provided market into the provided final
+// state, stops tracking it, and reconciles positions against the account's actual balances.
+//
+func (o *Service) finishOrder(market *market, state orderState) {
+  order := market.order
+
+  if order.state != state {
+    if err := order.transition(state); err != nil {
+      logger.Printf("Forcing %s order into a final state. (Error: %s)", market.asset, err)
+
+      order.state = state
+    }
+  }
+
+  logger.Printf(
+    "%s order %s is finished as %s. (Filled: %s of %s %s)",
+    order.side, order.id, order.state, order.filledQty, order.qty, market.asset,
+  )
+
+  market.order = nil
+
+  //
+  // Work out where the order left our position. This is only a best guess until the position has
+  // been reconciled against the account's actual balances.
+  //
+  if order.side == exchange.Buy && order.filledQty.GreaterThan(decimal.Zero) {
+    market.position = holding
+
+    if order.filledQuote.GreaterThan(decimal.Zero) {
+      market.enter(order.filledQuote.Div(order.filledQty))
+    } else {
+      market.enter(order.price)
+    }
+  } else if order.side == exchange.Buy {
+    market.position = waiting
+  } else if order.state == filled {
+    market.position = waiting
+    market.exit()
+  } else {
+    market.position = holding
+  }
+
+  if err := o.reconcile(); err != nil {
+    logger.Printf("Failed to reconcile positions against account balances. (Error: %s)", err)
+  }
+}
+
+//
+// reconcile retrieves the account's actual balances from the exchange and updates the tracked
+// holdings and position of every market to match them.
+//
+func (o *Service) reconcile() error {
+  //
+  // Retrieve the account's balances.
+  //
+  o.reconcileSeq++
+  seq := o.reconcileSeq
+
+  var resp exchange.Response
+  var err error
+
+  client := o.client
+
+  o.call(func() {
+    resp, err = client.RetrieveBalances()
+  })
+
+  if err != nil {
+    return err
+  }
+
+  //
+  // NOTE ~> Another reconciliation may have been started after this one and applied while the
+  //  balances were being retrieved, in which case these balances are older than the ones that are
+  //  already being tracked.
+  //
+  if seq < o.reconciledSeq {
+    logger.Printf("Discarding account balances that are older than the most recently reconciled ones.")
+
+    return nil
+  }
+
+  o.reconciledSeq = seq
+
+  //
+  // Pick out the balances that we care about.
+  //
+  // NOTE ~> Only free balances are tracked, as anything that is locked up in open orders cannot be
+  //  traded by us anyways.
+  //
+  usd := decimal.Zero
+  held := make(map[string]decimal.Decimal)
+
+  for _, balance := range resp.Balances() {
+    if balance.Asset() == QuoteAsset {
+      usd = *balance.Free()
+    } else if _, ok := o.markets[balance.Asset()]; ok {
+      held[balance.Asset()] = *balance.Free()
+    }
+  }
+
+  o.usd = usd
+
+  //
+  // Update the tracked position of each market to match what the account actually holds.
+  //
+  for _, asset := range o.assets {
+    market := o.markets[asset]
+    market.held = held[asset]
+
+    //
+    // Markets with orders in flight stay in their buying or selling positions until the orders are
+    // finished.
+    //
+    if market.inFlight() {
+      continue
+    }
+
+    if market.held.Truncate(o.qtyPrecision).GreaterThan(decimal.Zero) {
+      market.position = holding
+    } else {
+      market.position = waiting
+    }
+
+    logger.Printf(
+      "Reconciled %s position against account balances. Current holdings are %s.",
+      asset, aurora.Bold(aurora.Yellow(fmt.Sprintf("%s %s", market.held, asset))),
+    )
+  }
+
+  logger.Printf(
+    "Reconciled USD balance against account balances. Current holdings are %s.",
+    aurora.Bold(aurora.Green(fmt.Sprintf("%s %s", o.usd, QuoteAsset))),
+  )
+
+  return nil
+}
+
+//
+// newSizer instantiates the built-in sizer with the provided name as configured by the command line
+// flags.
+//
+func newSizer(name string) (Sizer, error) {
+  switch name {
+  case "all":
+    return AllSizer{}, nil
+  case "fixed":
+    return FixedSizer{Amount: decimal.NewFromFloat(*cfgSizingAmt)}, nil
+  case "fraction":
+    return FractionSizer{Fraction: decimal.NewFromFloat(*cfgSizingFraction)}, nil
+  case "atr":
+    return ATRSizer{Risk: decimal.NewFromFloat(*cfgSizingRisk), Multiple: decimal.NewFromFloat(*cfgSizingATRMult)}, nil
+  case "kelly":
+    return KellySizer{
+      Scale:     decimal.NewFromFloat(*cfgSizingKellyScl),
+      MinTrades: *cfgSizingKellyMin,
+      Fallback:  decimal.NewFromFloat(*cfgSizingFraction),
+    }, nil
+  }
+
+  return nil, fmt.Errorf("unknown sizing policy %s (valid values are all, fixed, fraction, atr, and kelly)", name)
+}
@@ -0,0 +1,131 @@
+package broker
+
+import (
+  "github.com/lukehollenback/goose/exchange"
+  "github.com/lukehollenback/goose/exchange/file"
+  "github.com/shopspring/decimal"
+  "sync"
+  "testing"
+  "time"
+)
+
+//
+// blockingClient is an exchange client whose order placements block until they are released, as if
+// the exchange had stopped responding.
+//
+type blockingClient struct {
+  placing chan bool // Receives whenever an order begins to be placed.
+  release chan bool // Must be sent to before an order placement returns.
+}
+
+func (o *blockingClient) RetrieveCandles(symbol string, interval exchange.Interval, start time.Time, end time.Time, limit int) (exchange.Response, error) {
+  return file.NewResponse(nil), nil
+}
+
+func (o *blockingClient) RetrieveSymbol(source string, dest string) string {
+  return source + dest
+}
+
+func (o *blockingClient) Auth(key string, secret string) (exchange.Response, error) {
+  return nil, nil
+}
+
+func (o *blockingClient) PlaceMarketOrder(symbol string, side exchange.OrderSide, quantity decimal.Decimal) (exchange.Response, error) {
+  o.placing <- true
+  <-o.release
+
+  return file.NewResponse(nil), nil
+}
+
+func (o *blockingClient) PlaceLimitOrder(symbol string, side exchange.OrderSide, quantity decimal.Decimal, price decimal.Decimal, timeInForce exchange.TimeInForce) (exchange.Response, error) {
+  return o.PlaceMarketOrder(symbol, side, quantity)
+}
+
+func (o *blockingClient) CancelOrder(symbol string, orderID string) (exchange.Response, error) {
+  return file.NewResponse(nil), nil
+}
+
+func (o *blockingClient) RetrieveOrder(symbol string, orderID string) (exchange.Response, error) {
+  return file.NewResponse(nil), nil
+}
+
+func (o *blockingClient) RetrieveBalances() (exchange.Response, error) {
+  return file.NewResponse(nil), nil
+}
+
+func TestMarkIsNotBlockedByOrderPlacement(t *testing.T) {
+  client := &blockingClient{placing: make(chan bool), release: make(chan bool)}
+  start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+  o := &Service{
+    mu:           &sync.Mutex{},
+    assets:       []string{"BTC"},
+    markets:      map[string]*market{"BTC": newMarket("BTC", "BTCUSD", 14)},
+    client:       client,
+    qtyPrecision: 8,
+    orderType:    exchange.Market,
+    orderTimeout: time.Hour,
+    usd:          decimal.NewFromInt(1000),
+    ledger:       NewLedger(),
+    sizer:        FixedSizer{Amount: decimal.NewFromInt(1000)},
+    sizingCaps:   &sizingCaps{def: decimal.Zero, markets: make(map[string]decimal.Decimal)},
+  }
+
+  market := o.markets["BTC"]
+  market.position = waiting
+
+  //
+  // Enter a position, and wait for the exchange to stop responding while the order is placed.
+  //
+  signalled := make(chan bool)
+
+  go func() {
+    o.Signal("BTC", "test", UptrendDetected, decimal.NewFromInt(100), start)
+    signalled <- true
+  }()
+
+  <-client.placing
+
+  //
+  // Marks must still go through in the meantime, while signals in the same market are deferred
+  // until the order has been placed.
+  //
+  marked := make(chan bool)
+
+  go func() {
+    o.Mark("BTC", decimal.NewFromInt(101), start.Add(time.Minute))
+    o.Signal("BTC", "test", DowntrendDetected, decimal.NewFromInt(99), start.Add(2*time.Minute))
+    marked <- true
+  }()
+
+  select {
+  case <-marked:
+  case <-time.After(5 * time.Second):
+    t.Fatalf("Expected a mark and a signal to go through while an order is being placed.")
+  }
+
+  o.mu.Lock()
+
+  if market.deferred == nil || market.deferred.signal != DowntrendDetected {
+    t.Errorf("Expected the downtrend signal to have been deferred.")
+  }
+
+  o.mu.Unlock()
+
+  //
+  // Once the exchange responds, the deferred signal should cancel the buy order (which never filled).
+  //
+  client.release <- true
+  <-signalled
+
+  o.mu.Lock()
+  defer o.mu.Unlock()
+
+  if market.inFlight() || market.position != waiting || market.busy || market.deferred != nil {
+    t.Errorf("Expected the buy order to have been cancelled (Position: %d, Busy: %t).", market.position, market.busy)
+  }
+
+  if !market.lastPrice.Equal(decimal.NewFromInt(99)) {
+    t.Errorf("Expected the market to have been marked at 99 but it was instead marked at %s.", market.lastPrice)
+  }
+}
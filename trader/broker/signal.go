@@ -12,3 +12,7 @@ const (
   UptrendDetected
   DowntrendDetected
 )
+
+func (o Signal) String() string {
+  return [...]string{"None", "UptrendDetected", "DowntrendDetected"}[o]
+}
@@ -0,0 +1,238 @@
+package broker
+
+import (
+  "fmt"
+  "github.com/shopspring/decimal"
+  "strings"
+)
+
+//
+// SizingInput describes everything that a Sizer may take into account when deciding how large a
+// new position should be.
+//
+type SizingInput struct {
+  Asset   string          // The asset whose market the position is being entered in.
+  Price   decimal.Decimal // The price that the position is expected to be entered at.
+  Free    decimal.Decimal // The free USD balance that is available to spend.
+  Equity  decimal.Decimal // The total value (in USD) of everything that is held.
+  Waiting int             // The number of markets (including this one) that are waiting to enter a position.
+  ATR     decimal.Decimal // The average true range of the asset's market, or zero if it is not yet known.
+  Stats   TradeStats      // Statistics about the trades that have been closed out so far.
+}
+
+//
+// SizeHint is advice from a strategy about how a new position in its market should be sized. Zero
+// values mean that the strategy has no opinion.
+//
+type SizeHint struct {
+  ATR decimal.Decimal // The average true range of the market, as measured on the strategy's own timeframe.
+}
+
+//
+// Sizer decides how much USD should be spent on entering a new position. The amount that it returns
+// is further limited by any per-market caps and by the free USD balance.
+//
+type Sizer interface {
+  Size(in SizingInput) decimal.Decimal
+}
+
+//
+// TradeStats tracks the outcomes of the trades that have been closed out.
+//
+type TradeStats struct {
+  Wins    int
+  Losses  int
+  WinSum  decimal.Decimal // The sum of the returns (as fractions) of all winning trades.
+  LossSum decimal.Decimal // The sum of the returns (as positive fractions) of all losing trades.
+}
+
+//
+// record adds the return (as a fraction, e.g. 0.02 for 2%) of a closed trade to the statistics.
+//
+func (o *TradeStats) record(ret decimal.Decimal) {
+  if ret.GreaterThan(decimal.Zero) {
+    o.Wins++
+    o.WinSum = o.WinSum.Add(ret)
+  } else {
+    o.Losses++
+    o.LossSum = o.LossSum.Add(ret.Abs())
+  }
+}
+
+//
+// tradeStats builds statistics about the outcomes of the provided trades.
+//
+func tradeStats(trades []Trade) TradeStats {
+  stats := TradeStats{WinSum: decimal.Zero, LossSum: decimal.Zero}
+
+  for _, trade := range trades {
+    stats.record(trade.Return())
+  }
+
+  return stats
+}
+
+//
+// AllSizer splits the free USD balance evenly between all of the markets that are waiting to enter
+// a position.
+//
+type AllSizer struct{}
+
+func (o AllSizer) Size(in SizingInput) decimal.Decimal {
+  if in.Waiting <= 0 {
+    return decimal.Zero
+  }
+
+  return in.Free.Div(decimal.NewFromInt(int64(in.Waiting)))
+}
+
+//
+// FixedSizer spends a fixed amount of USD on every position.
+//
+type FixedSizer struct {
+  Amount decimal.Decimal
+}
+
+func (o FixedSizer) Size(in SizingInput) decimal.Decimal {
+  return o.Amount
+}
+
+//
+// FractionSizer spends a fixed fraction of total equity on every position.
+//
+type FractionSizer struct {
+  Fraction decimal.Decimal
+}
+
+func (o FractionSizer) Size(in SizingInput) decimal.Decimal {
+  return in.Equity.Mul(o.Fraction)
+}
+
+//
+// ATRSizer sizes positions so that a move of a multiple of the market's average true range against
+// the position loses a fixed fraction of total equity. Volatile markets therefore get smaller
+// positions. Until the average true range is known, nothing is spent.
+//
+type ATRSizer struct {
+  Risk     decimal.Decimal // The fraction of equity to risk on each position.
+  Multiple decimal.Decimal // The multiple of the average true range that the risk is measured against.
+}
+
+func (o ATRSizer) Size(in SizingInput) decimal.Decimal {
+  if !in.ATR.GreaterThan(decimal.Zero) {
+    return decimal.Zero
+  }
+
+  qty := in.Equity.Mul(o.Risk).Div(in.ATR.Mul(o.Multiple))
+
+  return qty.Mul(in.Price)
+}
+
+//
+// KellySizer spends a scaled Kelly fraction of total equity on every position, as derived from the
+// win rate and win/loss ratio of the trades closed out so far. Until enough trades have been closed
+// out for the statistics to mean anything, the fallback fraction is used instead.
+//
+// NOTE ~> Kelly fraction = W - (1 - W) / R, where W is the win rate and R is the ratio of the
+//  average win to the average loss.
+//
+type KellySizer struct {
+  Scale     decimal.Decimal // How much of the full Kelly fraction to use (e.g. 0.5 for "half Kelly").
+  MinTrades int             // How many trades must be closed out before the Kelly fraction is used.
+  Fallback  decimal.Decimal // The fraction of equity to use until enough trades have been closed out.
+}
+
+func (o KellySizer) Size(in SizingInput) decimal.Decimal {
+  trades := in.Stats.Wins + in.Stats.Losses
+
+  if trades < o.MinTrades || trades == 0 {
+    return in.Equity.Mul(o.Fallback)
+  }
+
+  //
+  // Without any losses (or any wins), the ratio is meaningless, so bet the full (scaled) equity (or
+  // nothing).
+  //
+  if in.Stats.Losses == 0 {
+    return in.Equity.Mul(o.Scale)
+  } else if in.Stats.Wins == 0 {
+    return decimal.Zero
+  }
+
+  winRate := decimal.NewFromInt(int64(in.Stats.Wins)).Div(decimal.NewFromInt(int64(trades)))
+  avgWin := in.Stats.WinSum.Div(decimal.NewFromInt(int64(in.Stats.Wins)))
+  avgLoss := in.Stats.LossSum.Div(decimal.NewFromInt(int64(in.Stats.Losses)))
+
+  if !avgLoss.GreaterThan(decimal.Zero) {
+    return in.Equity.Mul(o.Scale)
+  }
+
+  kelly := winRate.Sub(decimal.NewFromInt(1).Sub(winRate).Div(avgWin.Div(avgLoss)))
+
+  if !kelly.GreaterThan(decimal.Zero) {
+    return decimal.Zero
+  }
+
+  return in.Equity.Mul(kelly).Mul(o.Scale)
+}
+
+//
+// sizingCaps limits how much USD may be spent on a single position, either per market or for all
+// markets without a specific cap.
+//
+type sizingCaps struct {
+  def     decimal.Decimal            // The cap for markets without a specific one. Zero means uncapped.
+  markets map[string]decimal.Decimal // The caps for specific markets, keyed by asset.
+}
+
+//
+// parseSizingCaps parses per-market caps from their flag representation – a comma-separated list
+// of caps for specific assets (e.g. "BTC:500") and/or a single cap for every other asset (e.g.
+// "250").
+//
+func parseSizingCaps(value string) (*sizingCaps, error) {
+  caps := &sizingCaps{def: decimal.Zero, markets: make(map[string]decimal.Decimal)}
+
+  for _, element := range strings.Split(value, ",") {
+    if element = strings.TrimSpace(element); element == "" {
+      continue
+    }
+
+    asset := ""
+    amtStr := element
+
+    if i := strings.Index(element, ":"); i >= 0 {
+      asset = strings.TrimSpace(element[:i])
+      amtStr = strings.TrimSpace(element[i+1:])
+    }
+
+    amt, err := decimal.NewFromString(amtStr)
+    if err != nil || !amt.GreaterThan(decimal.Zero) {
+      return nil, fmt.Errorf("could not parse position size cap %s", element)
+    }
+
+    if asset == "" {
+      caps.def = amt
+    } else {
+      caps.markets[asset] = amt
+    }
+  }
+
+  return caps, nil
+}
+
+//
+// limit applies the cap of the specified asset's market to the provided amount of USD.
+//
+func (o *sizingCaps) limit(asset string, amt decimal.Decimal) decimal.Decimal {
+  limit, ok := o.markets[asset]
+  if !ok {
+    limit = o.def
+  }
+
+  if limit.GreaterThan(decimal.Zero) && amt.GreaterThan(limit) {
+    return limit
+  }
+
+  return amt
+}
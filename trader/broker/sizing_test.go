@@ -0,0 +1,100 @@
+package broker
+
+import (
+  "github.com/shopspring/decimal"
+  "testing"
+)
+
+func TestATRSizer(t *testing.T) {
+  //
+  // Risking 1% of 10,000 USD on a move of 2 × 50 USD against the position means holding 1 unit.
+  //
+  sizer := ATRSizer{Risk: decimal.NewFromFloat(0.01), Multiple: decimal.NewFromInt(2)}
+
+  spend := sizer.Size(SizingInput{
+    Price:  decimal.NewFromInt(2000),
+    Equity: decimal.NewFromInt(10000),
+    ATR:    decimal.NewFromInt(50),
+  })
+
+  if !spend.Equal(decimal.NewFromInt(2000)) {
+    t.Errorf("Expected to spend 2,000 USD but instead would spend %s.", spend)
+  }
+}
+
+func TestSizeHintOverridesTrackedATR(t *testing.T) {
+  market := newMarket("BTC", "", 1)
+  market.atr.Add(decimal.NewFromInt(110), decimal.NewFromInt(100), decimal.NewFromInt(105))
+
+  if atr := market.sizingATR(); !atr.Equal(decimal.NewFromInt(10)) {
+    t.Fatalf("Expected to size against the tracked ATR of 10 but instead would size against %s.", atr)
+  }
+
+  market.hint = SizeHint{ATR: decimal.NewFromInt(40)}
+
+  if atr := market.sizingATR(); !atr.Equal(decimal.NewFromInt(40)) {
+    t.Errorf("Expected to size against the hinted ATR of 40 but instead would size against %s.", atr)
+  }
+}
+
+func TestKellySizer(t *testing.T) {
+  //
+  // A 60% win rate with wins twice as large as losses gives a Kelly fraction of 0.6 - 0.4 / 2 = 0.4.
+  //
+  sizer := KellySizer{Scale: decimal.NewFromFloat(0.5), MinTrades: 5, Fallback: decimal.NewFromFloat(0.1)}
+  stats := TradeStats{}
+
+  for i := 0; i < 3; i++ {
+    stats.record(decimal.NewFromFloat(0.02))
+  }
+
+  in := SizingInput{Equity: decimal.NewFromInt(1000), Stats: stats}
+
+  if spend := sizer.Size(in); !spend.Equal(decimal.NewFromInt(100)) {
+    t.Errorf("Expected to fall back to spending 100 USD but instead would spend %s.", spend)
+  }
+
+  for i := 0; i < 2; i++ {
+    stats.record(decimal.NewFromFloat(-0.01))
+  }
+
+  in.Stats = stats
+
+  if spend := sizer.Size(in); !spend.Equal(decimal.NewFromInt(200)) {
+    t.Errorf("Expected to spend 200 USD but instead would spend %s.", spend)
+  }
+}
+
+func TestTradeStatsFromTrades(t *testing.T) {
+  stats := tradeStats([]Trade{
+    {Cost: decimal.NewFromInt(100), Proceeds: decimal.NewFromInt(102)},
+    {Cost: decimal.NewFromInt(200), Proceeds: decimal.NewFromInt(198)},
+  })
+
+  if stats.Wins != 1 || !stats.WinSum.Equal(decimal.NewFromFloat(0.02)) {
+    t.Errorf("Expected a single 2%% win but got %d wins summing to %s.", stats.Wins, stats.WinSum)
+  }
+
+  if stats.Losses != 1 || !stats.LossSum.Equal(decimal.NewFromFloat(0.01)) {
+    t.Errorf("Expected a single 1%% loss but got %d losses summing to %s.", stats.Losses, stats.LossSum)
+  }
+}
+
+func TestSizingCaps(t *testing.T) {
+  caps, err := parseSizingCaps("250, BTC:500")
+  if err != nil {
+    t.Fatalf("Failed to parse position size caps. (Error: %s)", err)
+  }
+
+  if amt := caps.limit("BTC", decimal.NewFromInt(800)); !amt.Equal(decimal.NewFromInt(500)) {
+    t.Errorf("Expected BTC positions to be capped at 500 USD but instead were capped at %s.", amt)
+  }
+
+  if amt := caps.limit("ETH", decimal.NewFromInt(800)); !amt.Equal(decimal.NewFromInt(250)) {
+    t.Errorf("Expected ETH positions to be capped at 250 USD but instead were capped at %s.", amt)
+  }
+
+  if amt := caps.limit("ETH", decimal.NewFromInt(100)); !amt.Equal(decimal.NewFromInt(100)) {
+    t.Errorf("Expected positions under the cap to be left alone, but instead were capped at %s.", amt)
+  }
+}
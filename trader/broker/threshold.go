@@ -0,0 +1,80 @@
+package broker
+
+import (
+  "fmt"
+  "github.com/shopspring/decimal"
+  "strings"
+)
+
+//
+// Threshold describes a distance away from (or an amount of) some reference amount of USD (e.g. the
+// entry price of a position, or total equity). It is either a percentage of the reference amount or
+// an absolute amount of USD. It is used by protective exits, limit order offsets, and slippage, as
+// well as by the limits of the risk manager.
+//
+type Threshold struct {
+  percent bool
+  amt     decimal.Decimal
+}
+
+//
+// ParseThreshold parses a threshold from its flag representation – either a percentage (e.g. "2%")
+// or an absolute amount of USD (e.g. "150"). An empty string disables the threshold, in which case
+// nil is returned.
+//
+func ParseThreshold(value string) (*Threshold, error) {
+  value = strings.TrimSpace(value)
+
+  if value == "" {
+    return nil, nil
+  }
+
+  amt, err := decimal.NewFromString(strings.TrimSuffix(value, "%"))
+  if err != nil {
+    return nil, fmt.Errorf("could not parse threshold %s (%s)", value, err)
+  }
+
+  if !amt.GreaterThan(decimal.Zero) {
+    return nil, fmt.Errorf("threshold %s must be positive", value)
+  }
+
+  return &Threshold{percent: strings.HasSuffix(value, "%"), amt: amt}, nil
+}
+
+//
+// Of returns the absolute amount of USD that the threshold amounts to given the provided reference
+// amount.
+//
+func (o *Threshold) Of(ref decimal.Decimal) decimal.Decimal {
+  if o.percent {
+    return ref.Mul(o.amt).Div(decimal.NewFromInt(100))
+  }
+
+  return o.amt
+}
+
+//
+// Below returns the price that is the threshold's distance below the provided reference price.
+//
+func (o *Threshold) Below(ref decimal.Decimal) decimal.Decimal {
+  return ref.Sub(o.Of(ref))
+}
+
+//
+// Above returns the price that is the threshold's distance above the provided reference price.
+//
+func (o *Threshold) Above(ref decimal.Decimal) decimal.Decimal {
+  return ref.Add(o.Of(ref))
+}
+
+func (o *Threshold) String() string {
+  if o == nil {
+    return "disabled"
+  }
+
+  if o.percent {
+    return fmt.Sprintf("%s%%", o.amt)
+  }
+
+  return fmt.Sprintf("%s USD", o.amt)
+}
@@ -0,0 +1,34 @@
+package broker
+
+import (
+  "github.com/shopspring/decimal"
+  "testing"
+)
+
+func TestParseThreshold(t *testing.T) {
+  percent, err := ParseThreshold("2%")
+  if err != nil {
+    t.Fatalf("Failed to parse percentage threshold. (Error: %s)", err)
+  }
+
+  if trigger := percent.Below(decimal.NewFromInt(10000)); !trigger.Equal(decimal.NewFromInt(9800)) {
+    t.Errorf("Expected a 2%% threshold to be 9,800 below 10,000 but it was instead %s.", trigger)
+  }
+
+  absolute, err := ParseThreshold("150")
+  if err != nil {
+    t.Fatalf("Failed to parse absolute threshold. (Error: %s)", err)
+  }
+
+  if trigger := absolute.Above(decimal.NewFromInt(10000)); !trigger.Equal(decimal.NewFromInt(10150)) {
+    t.Errorf("Expected a 150 USD threshold to be 10,150 above 10,000 but it was instead %s.", trigger)
+  }
+
+  if disabled, err := ParseThreshold(""); disabled != nil || err != nil {
+    t.Errorf("Expected an empty threshold to be disabled.")
+  }
+
+  if _, err := ParseThreshold("-5%"); err == nil {
+    t.Errorf("Expected a negative threshold to fail to parse.")
+  }
+}
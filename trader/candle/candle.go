@@ -116,6 +116,28 @@ func (o *Candle) Append(time time.Time, amt decimal.Decimal) error {
   return nil
 }
 
+//
+// fold calculates the provided, later candle into the candle. It is expected that the provided
+// candle falls within the window in time that the candle represents a snapshot of.
+//
+func (o *Candle) fold(other *Candle) {
+  o.mu.Lock()
+  defer o.mu.Unlock()
+
+  o.close = other.close
+
+  if other.high.GreaterThan(o.high) {
+    o.high = other.high
+  }
+
+  if other.low.LessThan(o.low) {
+    o.low = other.low
+  }
+
+  o.total = o.total.Add(other.total)
+  o.cnt = o.cnt.Add(other.cnt)
+}
+
 //
 // End returns the ending instant of time of the candle.
 //
@@ -1,11 +1,29 @@
 package candle
 
+import (
+  "sort"
+  "time"
+)
+
+//
+// Candles holds a single candle reference for each interval of candle, keyed by the interval. Only
+// intervals that are relevant for the use case (e.g. those that had candles close out) are present.
+//
+type Candles map[time.Duration]*Candle
+
 //
-// Candles holds a single candle reference for each granularity of candle. Each reference may be
-// nil if not relevant for the use case.
+// Intervals returns the intervals of the held candles, sorted from shortest to longest.
 //
-type Candles struct {
-  OneMin     *Candle
-  FiveMin    *Candle
-  FifteenMin *Candle
+func (o Candles) Intervals() []time.Duration {
+  intervals := make([]time.Duration, 0, len(o))
+
+  for interval := range o {
+    intervals = append(intervals, interval)
+  }
+
+  sort.Slice(intervals, func(i, j int) bool {
+    return intervals[i] < intervals[j]
+  })
+
+  return intervals
 }
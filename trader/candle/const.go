@@ -5,3 +5,6 @@ import "time"
 const OneMin = 1 * time.Minute
 const FiveMin = 5 * time.Minute
 const FifteenMin = 15 * time.Minute
+const OneHour = 1 * time.Hour
+const FourHour = 4 * time.Hour
+const OneDay = 24 * time.Hour
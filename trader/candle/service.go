@@ -1,8 +1,8 @@
 package candle
 
 import (
-  "errors"
   "fmt"
+  "sort"
   "github.com/lukehollenback/goose/constants"
   "github.com/lukehollenback/goose/trader/writer"
   "github.com/shopspring/decimal"
@@ -32,10 +32,9 @@ func init() {
 // Service represents a candle store service instance.
 //
 type Service struct {
-  mu              *sync.Mutex
-  oneMinStore     *Store
-  fiveMinStore    *Store
-  fifteenMinStore *Store
+  mu        *sync.Mutex
+  intervals []time.Duration                     // The intervals of candles that are being built, from shortest to longest.
+  stores    map[string]map[time.Duration]*Store // The candle store for each interval of candles that is being built, for each asset.
 }
 
 //
@@ -44,13 +43,82 @@ type Service struct {
 func Instance() *Service {
   once.Do(func() {
     o = &Service{
-      mu: &sync.Mutex{},
+      mu:        &sync.Mutex{},
+      intervals: []time.Duration{OneMin, FiveMin, FifteenMin},
+      stores:    make(map[string]map[time.Duration]*Store),
     }
   })
 
   return o
 }
 
+//
+// AddInterval tells the candle store service to also build candles of the provided interval (e.g.
+// one hour or one day) for every asset. Intervals must be whole numbers of minutes, and cannot be
+// added once the candle stores of any asset have been initialized.
+//
+func (o *Service) AddInterval(interval time.Duration) error {
+  o.mu.Lock()
+  defer o.mu.Unlock()
+
+  //
+  // Make sure that the interval is valid.
+  //
+  if err := ValidateInterval(interval); err != nil {
+    return err
+  }
+
+  //
+  // Bail out if we are already building candles of the interval.
+  //
+  for _, v := range o.intervals {
+    if v == interval {
+      return nil
+    }
+  }
+
+  //
+  // Make sure that the candle stores have not already been initialized.
+  //
+  if len(o.stores) > 0 {
+    return fmt.Errorf("cannot add candle interval %s after the candle stores have been initialized", interval)
+  }
+
+  //
+  // Actually add the interval, keeping our intervals sorted from shortest to longest.
+  //
+  o.intervals = append(o.intervals, interval)
+
+  sort.Slice(o.intervals, func(i, j int) bool {
+    return o.intervals[i] < o.intervals[j]
+  })
+
+  return nil
+}
+
+//
+// Intervals returns the intervals of candles that are being built, from shortest to longest.
+//
+func (o *Service) Intervals() []time.Duration {
+  o.mu.Lock()
+  defer o.mu.Unlock()
+
+  return append([]time.Duration{}, o.intervals...)
+}
+
+//
+// ValidateInterval returns an error if candles of the provided interval cannot be built. Intervals
+// must be whole numbers of minutes, as one minute candles are the smallest that are ever provided
+// to the candle store service.
+//
+func ValidateInterval(interval time.Duration) error {
+  if interval <= 0 || interval%OneMin != 0 {
+    return fmt.Errorf("candle interval %s is not a positive, whole number of minutes", interval)
+  }
+
+  return nil
+}
+
 //
 // Start implements the Service interface's described method.
 //
@@ -92,101 +160,128 @@ func (o *Service) Stop() (<-chan bool, error) {
 }
 
 //
-// Init (re)initializes the candle store service's candle stores with the provided initial candles.
-// It should be called prior to processing any new trades into the service to seed the candle stores
-// with current candle values.
+// Init (re)initializes the specified asset's candle stores with the provided initial trade. It
+// should be called prior to processing any new trades of the asset into the service to seed its
+// candle stores with current candle values. The initial candle of each store is aligned to the
+// start of the interval that the trade falls within (e.g. the top of the hour for one hour candles).
 //
-func (o *Service) Init(oneMinCandle *Candle, fiveMinCandle *Candle, fifteenMinCandle *Candle) error {
+func (o *Service) Init(asset string, tradeTime time.Time, amt decimal.Decimal) error {
   o.mu.Lock()
   defer o.mu.Unlock()
 
-  var err error
+  stores := make(map[time.Duration]*Store)
 
-  //
-  // Initialize the fifteen-minute candle store.
-  //
-  if o.oneMinStore, err = CreateStore(OneMin, oneMinCandle); err != nil {
-    return err
-  }
+  for _, interval := range o.intervals {
+    store, err := CreateStore(interval, CreateCandle(tradeTime.Truncate(interval), interval, amt))
+    if err != nil {
+      return err
+    }
 
-  //
-  // Initialize the fifteen-minute candle store.
-  //
-  if o.fifteenMinStore, err = CreateStore(FifteenMin, fifteenMinCandle); err != nil {
-    return err
+    stores[interval] = store
   }
 
-  //
-  // Initialize the five-minute candle store.
-  //
-  if o.fiveMinStore, err = CreateStore(FiveMin, fiveMinCandle); err != nil {
-    return err
-  }
+  o.stores[asset] = stores
 
   return nil
 }
 
 //
-// Append adds the provided trade to all of the necessary candle stores. Returns a structure holding
-// references to any candles that were closed out by the append.
+// Initialized returns whether or not the specified asset's candle stores have been initialized yet.
 //
-func (o *Service) Append(time time.Time, amt decimal.Decimal) (*Candles, error) {
+func (o *Service) Initialized(asset string) bool {
+  o.mu.Lock()
+  defer o.mu.Unlock()
+
+  return len(o.stores[asset]) > 0
+}
+
+//
+// Append adds the provided trade of the specified asset to all of the asset's candle stores.
+// Returns a structure holding references to any candles that were closed out by the append.
+//
+func (o *Service) Append(asset string, time time.Time, amt decimal.Decimal) (Candles, error) {
   o.mu.Lock()
   defer o.mu.Unlock()
 
   //
   // Ensure that the necessary candle stores have been initialized.
   //
-  if o.oneMinStore == nil || o.fiveMinStore == nil || o.fifteenMinStore == nil {
-    return nil, errors.New(
-      "cannot append trade before the candle store service's candle stores have been " +
-          "initialized",
+  stores := o.stores[asset]
+
+  if len(stores) == 0 {
+    return nil, fmt.Errorf(
+      "cannot append %s trade before the asset's candle stores have been initialized", asset,
     )
   }
 
   //
   // Create a new structure to hold references to any candles that were closed out by this append.
   //
-  closedCandles := &Candles{}
+  closedCandles := make(Candles)
 
   //
-  // Append the trade to the one-minute candle store. We also report one-minute candle closes to
-  // the Writer Service so that it can track the moving price of the asset being traded against
-  // any other data points it is tracking.
+  // Append the trade to each candle store. We also report closes of the shortest interval of
+  // candles to the Writer Service so that it can track the moving price of the asset being traded
+  // against any other data points it is tracking.
   //
-  createdNewCandle, err := o.oneMinStore.Append(time, amt)
-  if err != nil {
-    return nil, err
-  } else if createdNewCandle {
-    closedCandles.OneMin = o.oneMinStore.Previous()
+  for i, interval := range o.intervals {
+    store := stores[interval]
+
+    createdNewCandle, err := store.Append(time, amt)
+    if err != nil {
+      return nil, err
+    } else if createdNewCandle {
+      closed := store.Previous()
+      closedCandles[interval] = closed
 
-    _ = writer.Instance().Write(closedCandles.OneMin.End(), writer.ClosingPrice, closedCandles.OneMin.CloseAmt())
+      if i == 0 {
+        _ = writer.Instance().WriteFor(closed.End(), asset, writer.ClosingPrice, closed.CloseAmt())
+      }
 
-    logger.Printf("1 Min ↝ %s", closedCandles.OneMin)
+      logger.Printf("%s %s ↝ %s", asset, interval, closed)
+    }
   }
 
+  return closedCandles, nil
+}
+
+//
+// AppendCandle folds the provided one minute candle of the specified asset into all of the asset's
+// candle stores. This is how historical candles (e.g. during backtests) are provided to the service
+// in lieu of individual trades. The candle stores are created on the fly if they do not exist yet.
+// Returns a structure holding references to any candles that were closed out by the append.
+//
+func (o *Service) AppendCandle(asset string, candle *Candle) (Candles, error) {
+  o.mu.Lock()
+  defer o.mu.Unlock()
+
   //
-  // Append the trade to the five-minute candle store.
+  // Create a new structure to hold references to any candles that were closed out by this append.
   //
-  createdNewCandle, err = o.fiveMinStore.Append(time, amt)
-  if err != nil {
-    return nil, err
-  } else if createdNewCandle {
-    closedCandles.FiveMin = o.fiveMinStore.Previous()
-
-    logger.Printf("5 Min ↝ %s", closedCandles.FiveMin)
-  }
+  closedCandles := make(Candles)
 
   //
-  // Append the trade to the fifteen-minute candle store.
+  // Fold the candle into each candle store, creating the stores if necessary.
   //
-  createdNewCandle, err = o.fifteenMinStore.Append(time, amt)
-  if err != nil {
-    return nil, err
-  } else if createdNewCandle {
-    closedCandles.FifteenMin = o.fifteenMinStore.Previous()
+  stores, ok := o.stores[asset]
+  if !ok {
+    stores = make(map[time.Duration]*Store)
+    o.stores[asset] = stores
+  }
+
+  for _, interval := range o.intervals {
+    store, ok := stores[interval]
+    if !ok {
+      store = createEmptyStore(interval)
+      stores[interval] = store
+    }
 
-    logger.Printf("15 Min ↝ %s", closedCandles.FifteenMin)
+    closed, err := store.FoldCandle(candle)
+    if err != nil {
+      return nil, err
+    } else if closed != nil {
+      closedCandles[interval] = closed
+    }
   }
 
   return closedCandles, nil
@@ -13,6 +13,7 @@ type Store struct {
 	candles         []*Candle
 	lastCandleStart time.Time
 	lastCandleEnd   time.Time
+	lastCandleDone  bool // Whether or not the most recent candle has been closed out by a folded-in candle.
 }
 
 //
@@ -41,6 +42,19 @@ func CreateStore(interval time.Duration, initialCandle *Candle) (*Store, error)
 	return o, nil
 }
 
+//
+// createEmptyStore instantiates a new candle store that will hold candles of the specified duration
+// interval, but that does not hold any candles yet. Such stores must be built up by folding in
+// candles of shorter intervals.
+//
+func createEmptyStore(interval time.Duration) *Store {
+	return &Store{
+		mu:       &sync.Mutex{},
+		interval: interval,
+		candles:  make([]*Candle, 0),
+	}
+}
+
 //
 // Previous retrieves the last closed-out candle from the candle store. If one does not exist, it
 // simply returns nil.
@@ -92,10 +106,20 @@ func (o *Store) Append(time time.Time, amt decimal.Decimal) (bool, error) {
 	}
 
 	//
-	// Figure out if we need to create a new candle and do so if necessary.
+	// Figure out if we need to create a new candle and do so if necessary. If no trades occurred for
+	// one or more entire intervals (e.g. because the market is illiquid or because we lost our
+	// connection to the exchange), the new candle must skip over them so that it still lines up
+	// with the intervals of the candles that came before it.
 	//
 	if time.After(o.lastCandleEnd) {
-		if err := o.appendNewCandle(o.lastCandleEnd, amt); err != nil {
+		skipped := time.Sub(o.lastCandleEnd.Add(1)) / o.interval
+		start := o.lastCandleEnd.Add(skipped * o.interval)
+
+		if skipped > 0 {
+			logger.Printf("Skipped %d %s interval(s) in which no trades occurred.", skipped, o.interval)
+		}
+
+		if err := o.appendNewCandle(start, amt); err != nil {
 			return false, err
 		}
 
@@ -116,6 +140,71 @@ func (o *Store) Append(time time.Time, amt decimal.Decimal) (bool, error) {
 	return false, nil
 }
 
+//
+// FoldCandle folds a candle of a shorter (or equal) interval into the candle of the store's interval
+// that it falls within, creating that candle if necessary. Candles of the store's interval are
+// aligned to the start of their intervals (e.g. the top of the hour for one hour candles). If the
+// provided candle completes the candle that it was folded into – or if it falls within a later
+// interval than the most recent candle, which was thus never completed – the closed-out candle is
+// returned. Otherwise, nil is returned.
+//
+func (o *Store) FoldCandle(candle *Candle) (*Candle, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	//
+	// Validate that the candle actually fits within a single candle of the store's interval.
+	//
+	if candle.duration > o.interval {
+		return nil, fmt.Errorf(
+			"cannot fold candle of duration %s into candle store of %s candles",
+			candle.duration, o.interval,
+		)
+	}
+
+	//
+	// Figure out which interval the candle falls within and validate that we are not trying to modify
+	// a historical, closed-out candle in the candle store.
+	//
+	var closed *Candle
+
+	start := candle.start.Truncate(o.interval)
+
+	if len(o.candles) > 0 && start.Before(o.lastCandleStart) {
+		return nil, fmt.Errorf("cannot modify closed-out candles in candle store")
+	}
+
+	//
+	// Either start a brand-new candle or fold the provided candle into the most recent one.
+	//
+	if len(o.candles) == 0 || start.After(o.lastCandleStart) {
+		if len(o.candles) > 0 && !o.lastCandleDone {
+			closed = o.candles[len(o.candles)-1]
+		}
+
+		err := o.appendCandle(CreateFullCandle(
+			start, o.interval, candle.open, candle.close, candle.high, candle.low, candle.total, candle.cnt,
+		))
+		if err != nil {
+			return nil, err
+		}
+
+		o.lastCandleDone = false
+	} else {
+		o.candles[len(o.candles)-1].fold(candle)
+	}
+
+	//
+	// Close out the most recent candle if the provided candle completed it.
+	//
+	if !candle.End().Before(o.lastCandleEnd) {
+		o.lastCandleDone = true
+		closed = o.candles[len(o.candles)-1]
+	}
+
+	return closed, nil
+}
+
 //
 // appendNewCandle creates a brand-new candle with the provided initial values and adds it to the
 // candle store.
@@ -0,0 +1,69 @@
+package candle
+
+import (
+	"github.com/shopspring/decimal"
+	"testing"
+	"time"
+)
+
+func TestFoldCandleIntoHourlyStore(t *testing.T) {
+	store := createEmptyStore(OneHour)
+	start := time.Date(2020, 8, 25, 0, 0, 0, 0, time.UTC)
+
+	//
+	// Fold in a full hour of one minute candles (each of which closes one higher than the last) and
+	// make sure that the hourly candle is only closed out by the last of them.
+	//
+	for i := 0; i < 60; i++ {
+		amt := decimal.NewFromInt(int64(100 + i))
+		c := CreateFullCandle(start.Add(time.Duration(i)*OneMin), OneMin, amt, amt.Add(One), amt.Add(One), amt, amt, One)
+
+		closed, err := store.FoldCandle(c)
+		if err != nil {
+			t.Fatalf("Failed to fold candle %d. (Error: %s)", i, err)
+		}
+
+		if i < 59 && closed != nil {
+			t.Fatalf("Expected hourly candle to still be open after candle %d.", i)
+		} else if i == 59 && closed == nil {
+			t.Fatalf("Expected hourly candle to be closed out after the final candle.")
+		}
+	}
+
+	hourly := store.Current()
+
+	if !hourly.OpenAmt().Equal(decimal.NewFromInt(100)) || !hourly.CloseAmt().Equal(decimal.NewFromInt(160)) {
+		t.Errorf("Expected hourly candle to open at 100 and close at 160, but was instead %s.", hourly)
+	}
+
+	if !hourly.HighAmt().Equal(decimal.NewFromInt(160)) || !hourly.LowAmt().Equal(decimal.NewFromInt(100)) {
+		t.Errorf("Expected hourly candle to have a high of 160 and a low of 100, but was instead %s.", hourly)
+	}
+}
+
+func TestFoldCandleAcrossGap(t *testing.T) {
+	store := createEmptyStore(FiveMin)
+	start := time.Date(2020, 8, 25, 0, 0, 0, 0, time.UTC)
+	amt := decimal.NewFromInt(100)
+
+	//
+	// Fold in a single one minute candle, and then another one that falls two intervals later. The
+	// first five minute candle was never completed, so it must be closed out by the second.
+	//
+	if closed, _ := store.FoldCandle(CreateCandle(start, OneMin, amt)); closed != nil {
+		t.Fatalf("Expected five minute candle to still be open.")
+	}
+
+	closed, err := store.FoldCandle(CreateCandle(start.Add(12*OneMin), OneMin, amt))
+	if err != nil {
+		t.Fatalf("Failed to fold candle. (Error: %s)", err)
+	}
+
+	if closed == nil || !closed.End().Equal(start.Add(FiveMin)) {
+		t.Fatalf("Expected the first five minute candle to have been closed out.")
+	}
+
+	if current := store.Current(); !current.End().Equal(start.Add(FifteenMin)) {
+		t.Errorf("Expected the current five minute candle to end at %s, but it instead ends at %s.", start.Add(FifteenMin), current.End())
+	}
+}
@@ -0,0 +1,80 @@
+package indicators
+
+import (
+  "github.com/shopspring/decimal"
+)
+
+//
+// ATR is the average true range of a market, smoothed using Wilder's smoothing.
+//
+// NOTE ~> The true range of a candle is the largest of its range and the distances of its high and
+//  low from the previous candle's close. The average is seeded with the simple average of the
+//  first full period of true ranges.
+//
+type ATR struct {
+  period    decimal.Decimal
+  periodLen int
+  precision int32
+  count     int
+  prevClose decimal.Decimal
+  value     decimal.Decimal
+}
+
+//
+// NewATR instantiates a new average true range over the provided number of candles, rounded to the
+// provided number of decimals.
+//
+func NewATR(period int, precision int32) (*ATR, error) {
+  if err := validatePeriod("ATR", period); err != nil {
+    return nil, err
+  }
+
+  return &ATR{
+    period:    decimal.NewFromInt(int64(period)),
+    periodLen: period,
+    precision: precision,
+    prevClose: decimal.Zero,
+    value:     decimal.Zero,
+  }, nil
+}
+
+//
+// Add updates the average true range with the high, low, and close of a newly-closed candle.
+//
+func (o *ATR) Add(high decimal.Decimal, low decimal.Decimal, close decimal.Decimal) {
+  tr := high.Sub(low)
+
+  if o.count > 0 {
+    tr = decimal.Max(tr, high.Sub(o.prevClose).Abs(), low.Sub(o.prevClose).Abs())
+  }
+
+  o.prevClose = close
+  o.count++
+
+  switch {
+  case o.count < o.periodLen:
+    o.value = o.value.Add(tr)
+  case o.count == o.periodLen:
+    o.value = round(o.value.Add(tr).Div(o.period), o.precision)
+  default:
+    o.value = round(o.value.Mul(o.period.Sub(one)).Add(tr).Div(o.period), o.precision)
+  }
+}
+
+//
+// Ready returns whether or not a full period of candles has been added.
+//
+func (o *ATR) Ready() bool {
+  return o.count >= o.periodLen
+}
+
+//
+// Value returns the average true range, or zero if it is not yet ready.
+//
+func (o *ATR) Value() decimal.Decimal {
+  if !o.Ready() {
+    return decimal.Zero
+  }
+
+  return o.value
+}
@@ -0,0 +1,120 @@
+package indicators
+
+import (
+  "fmt"
+  "github.com/shopspring/decimal"
+)
+
+//
+// Bollinger represents Bollinger Bands, which are a simple moving average (the middle band) with an
+// upper and a lower band a multiple of the standard deviation of the same values away from it.
+//
+type Bollinger struct {
+  period     decimal.Decimal
+  multiplier decimal.Decimal
+  precision  int32
+  window     *window
+  sum        decimal.Decimal
+  sumSq      decimal.Decimal // The sum of the squares of the values in the window.
+  middle     decimal.Decimal
+  upper      decimal.Decimal
+  lower      decimal.Decimal
+}
+
+//
+// NewBollinger instantiates new Bollinger Bands over the provided number of values (commonly 20)
+// that are the provided multiple of the standard deviation (commonly 2) away from the middle band,
+// rounded to the provided number of decimals.
+//
+func NewBollinger(period int, multiplier decimal.Decimal, precision int32) (*Bollinger, error) {
+  if err := validatePeriod("Bollinger Band", period); err != nil {
+    return nil, err
+  }
+
+  if !multiplier.GreaterThan(decimal.Zero) {
+    return nil, fmt.Errorf("the Bollinger Band multiplier (%s) must be positive", multiplier)
+  }
+
+  return &Bollinger{
+    period:     decimal.NewFromInt(int64(period)),
+    multiplier: multiplier,
+    precision:  precision,
+    window:     newWindow(period),
+    sum:        decimal.Zero,
+    sumSq:      decimal.Zero,
+    middle:     decimal.Zero,
+    upper:      decimal.Zero,
+    lower:      decimal.Zero,
+  }, nil
+}
+
+//
+// Add updates the bands with the provided value.
+//
+func (o *Bollinger) Add(value decimal.Decimal) {
+  o.sum = o.sum.Add(value)
+  o.sumSq = o.sumSq.Add(value.Mul(value))
+
+  if evicted, ok := o.window.push(value); ok {
+    o.sum = o.sum.Sub(evicted)
+    o.sumSq = o.sumSq.Sub(evicted.Mul(evicted))
+  }
+
+  if !o.Ready() {
+    return
+  }
+
+  //
+  // NOTE ~> The population standard deviation is used, as is conventional for Bollinger Bands.
+  //  Variance = mean of the squares - square of the mean
+  //
+  mean := o.sum.Div(o.period)
+  variance := decimal.Max(o.sumSq.Div(o.period).Sub(mean.Mul(mean)), decimal.Zero)
+  offset := sqrt(variance).Mul(o.multiplier)
+
+  o.middle = round(mean, o.precision)
+  o.upper = round(mean.Add(offset), o.precision)
+  o.lower = round(mean.Sub(offset), o.precision)
+}
+
+//
+// Ready returns whether or not a full period of values has been added, and thus whether or not the
+// bands have been calculated.
+//
+func (o *Bollinger) Ready() bool {
+  return o.window.full
+}
+
+//
+// Middle returns the middle band. Returns zero until the bands are ready.
+//
+func (o *Bollinger) Middle() decimal.Decimal {
+  return o.middle
+}
+
+//
+// Upper returns the upper band. Returns zero until the bands are ready.
+//
+func (o *Bollinger) Upper() decimal.Decimal {
+  return o.upper
+}
+
+//
+// Lower returns the lower band. Returns zero until the bands are ready.
+//
+func (o *Bollinger) Lower() decimal.Decimal {
+  return o.lower
+}
+
+//
+// Width returns the distance between the upper and lower bands as a fraction of the middle band
+// (e.g. 0.05 for 5%). Narrow bands indicate a period of low volatility (a "squeeze"). Returns zero
+// until the bands are ready.
+//
+func (o *Bollinger) Width() decimal.Decimal {
+  if o.middle.IsZero() {
+    return decimal.Zero
+  }
+
+  return o.upper.Sub(o.lower).Div(o.middle)
+}
@@ -0,0 +1,80 @@
+package indicators
+
+import (
+  "github.com/shopspring/decimal"
+)
+
+//
+// Donchian is a Donchian channel, whose upper band is the highest high and whose lower band is the
+// lowest low of the most recent candles.
+//
+type Donchian struct {
+  periodLen int
+  precision int32
+  count     int
+  highest   *extreme
+  lowest    *extreme
+}
+
+//
+// NewDonchian instantiates a new Donchian channel over the provided number of candles, with a
+// middle band that is rounded to the provided number of decimals.
+//
+func NewDonchian(period int, precision int32) (*Donchian, error) {
+  if err := validatePeriod("Donchian channel", period); err != nil {
+    return nil, err
+  }
+
+  return &Donchian{
+    periodLen: period,
+    precision: precision,
+    highest:   newExtreme(period, true),
+    lowest:    newExtreme(period, false),
+  }, nil
+}
+
+//
+// Add updates the channel with the high and low of a newly-closed candle.
+//
+func (o *Donchian) Add(high decimal.Decimal, low decimal.Decimal) {
+  o.highest.push(high)
+  o.lowest.push(low)
+  o.count++
+}
+
+//
+// Ready returns whether or not a full period of candles has been added.
+//
+func (o *Donchian) Ready() bool {
+  return o.count >= o.periodLen
+}
+
+//
+// Upper returns the highest high of the most recent candles. Returns zero until the channel is
+// ready.
+//
+func (o *Donchian) Upper() decimal.Decimal {
+  if !o.Ready() {
+    return decimal.Zero
+  }
+
+  return o.highest.value()
+}
+
+//
+// Lower returns the lowest low of the most recent candles. Returns zero until the channel is ready.
+//
+func (o *Donchian) Lower() decimal.Decimal {
+  if !o.Ready() {
+    return decimal.Zero
+  }
+
+  return o.lowest.value()
+}
+
+//
+// Middle returns the average of the upper and lower bands. Returns zero until the channel is ready.
+//
+func (o *Donchian) Middle() decimal.Decimal {
+  return round(o.Upper().Add(o.Lower()).Div(two), o.precision)
+}
@@ -0,0 +1,79 @@
+package indicators
+
+import (
+  "github.com/shopspring/decimal"
+)
+
+//
+// EMA is an exponential moving average. It implements the Series interface.
+//
+// NOTE ~> The average is seeded with the simple moving average of its first full period of values,
+//  and every value after that is smoothed into it. Each smoothed value is rounded before the next
+//  one is calculated from it.
+//
+type EMA struct {
+  precision int32
+  factor    decimal.Decimal
+  seed      *SMA
+  value     decimal.Decimal
+  ready     bool
+}
+
+//
+// NewEMA instantiates a new exponential moving average over the provided number of values, rounded
+// to the provided number of decimals.
+//
+func NewEMA(period int, precision int32) (*EMA, error) {
+  seed, err := NewSMA(period, precision)
+  if err != nil {
+    return nil, err
+  }
+
+  //
+  // NOTE ~> EMA Smoothing Factor = 2 ÷ (number of time periods + 1)
+  //
+  return &EMA{
+    precision: precision,
+    factor:    two.Div(decimal.NewFromInt(int64(period + 1))),
+    seed:      seed,
+    value:     decimal.Zero,
+  }, nil
+}
+
+//
+// Add implements the Series interface's described method.
+//
+func (o *EMA) Add(value decimal.Decimal) {
+  if !o.ready {
+    o.seed.Add(value)
+
+    if o.seed.Ready() {
+      o.value = o.seed.Value()
+      o.ready = true
+      o.seed = nil
+    }
+
+    return
+  }
+
+  //
+  // NOTE ~> EMA = (value - previous EMA) × smoothing factor + previous EMA
+  //
+  o.value = round(value.Sub(o.value).Mul(o.factor).Add(o.value), o.precision)
+}
+
+//
+// Ready implements the Series interface's described method. The average is ready once a full
+// period of values has been added to seed it.
+//
+func (o *EMA) Ready() bool {
+  return o.ready
+}
+
+//
+// Value implements the Series interface's described method. Returns zero until the average is
+// ready.
+//
+func (o *EMA) Value() decimal.Decimal {
+  return o.value
+}
@@ -0,0 +1,180 @@
+//
+// Package indicators provides streaming technical indicators for use by trading strategies. Each
+// indicator is updated incrementally as new data points (e.g. the close of each candle) are added
+// to it, and is calculated using decimals that are rounded to a configurable precision.
+//
+package indicators
+
+import (
+  "fmt"
+  "github.com/shopspring/decimal"
+  "math"
+)
+
+const (
+  //
+  // Unrounded may be provided as the precision of any indicator so that its values are never
+  // rounded.
+  //
+  Unrounded int32 = -1
+)
+
+var (
+  one     = decimal.NewFromInt(1)
+  two     = decimal.NewFromInt(2)
+  three   = decimal.NewFromInt(3)
+  fifty   = decimal.NewFromInt(50)
+  hundred = decimal.NewFromInt(100)
+)
+
+//
+// Series is an indicator that is calculated from a single series of values (e.g. the closing price
+// of each candle).
+//
+type Series interface {
+  Add(value decimal.Decimal)
+  Ready() bool
+  Value() decimal.Decimal
+}
+
+//
+// validatePeriod returns an error if the provided period cannot be used by an indicator.
+//
+func validatePeriod(name string, period int) error {
+  if period <= 0 {
+    return fmt.Errorf("the %s period (%d) must be positive", name, period)
+  }
+
+  return nil
+}
+
+//
+// round rounds the provided value to the provided number of decimals, unless the precision is
+// Unrounded.
+//
+func round(value decimal.Decimal, precision int32) decimal.Decimal {
+  if precision < 0 {
+    return value
+  }
+
+  return value.Round(precision)
+}
+
+//
+// sqrt returns the square root of the provided value, or zero if it is not positive.
+//
+// NOTE ~> The decimal library does not provide square roots, so a float is used to make a first
+//  guess which is then refined using Newton's method.
+//
+func sqrt(value decimal.Decimal) decimal.Decimal {
+  if !value.GreaterThan(decimal.Zero) {
+    return decimal.Zero
+  }
+
+  f, _ := value.Float64()
+  guess := decimal.NewFromFloat(math.Sqrt(f))
+
+  if !guess.GreaterThan(decimal.Zero) {
+    return decimal.Zero
+  }
+
+  for i := 0; i < 4; i++ {
+    guess = guess.Add(value.DivRound(guess, 16)).Div(two)
+  }
+
+  return guess
+}
+
+//
+// window is a fixed-size ring of the most recent values that have been added to an indicator.
+//
+type window struct {
+  values []decimal.Decimal
+  next   int
+  full   bool
+}
+
+//
+// newWindow instantiates a new, empty window of the provided size.
+//
+func newWindow(size int) *window {
+  return &window{values: make([]decimal.Decimal, size)}
+}
+
+//
+// push adds the provided value to the window. If the window was already full, the oldest value is
+// evicted and returned along with a true sentinel.
+//
+func (o *window) push(value decimal.Decimal) (decimal.Decimal, bool) {
+  evicted, full := o.values[o.next], o.full
+
+  o.values[o.next] = value
+  o.next = (o.next + 1) % len(o.values)
+
+  if o.next == 0 {
+    o.full = true
+  }
+
+  return evicted, full
+}
+
+//
+// extreme incrementally tracks the highest (or lowest) of the most recent values that have been
+// added to it.
+//
+// NOTE ~> Values that can never be the extreme again (because a newer value is at least as
+//  extreme) are discarded as soon as they are pushed past, so each value is only looked at a
+//  constant number of times.
+//
+type extreme struct {
+  period  int
+  highest bool
+  seen    int
+  indices []int
+  values  []decimal.Decimal
+}
+
+//
+// newExtreme instantiates a new tracker of the highest (or lowest) of the provided number of most
+// recent values.
+//
+func newExtreme(period int, highest bool) *extreme {
+  return &extreme{period: period, highest: highest}
+}
+
+//
+// push adds the provided value to the tracker.
+//
+func (o *extreme) push(value decimal.Decimal) {
+  for n := len(o.values); n > 0; n = len(o.values) {
+    last := o.values[n-1]
+
+    if (o.highest && last.GreaterThan(value)) || (!o.highest && last.LessThan(value)) {
+      break
+    }
+
+    o.indices = o.indices[:n-1]
+    o.values = o.values[:n-1]
+  }
+
+  o.indices = append(o.indices, o.seen)
+  o.values = append(o.values, value)
+  o.seen++
+
+  if o.indices[0] <= o.seen-1-o.period {
+    o.indices = o.indices[1:]
+    o.values = o.values[1:]
+  }
+}
+
+//
+// value returns the highest (or lowest) of the most recent values, or zero if none have been
+// pushed.
+//
+func (o *extreme) value() decimal.Decimal {
+  if len(o.values) == 0 {
+    return decimal.Zero
+  }
+
+  return o.values[0]
+}
@@ -0,0 +1,201 @@
+package indicators
+
+import (
+  "github.com/shopspring/decimal"
+  "testing"
+)
+
+//
+// series converts the provided integers into decimals.
+//
+func series(values ...int64) []decimal.Decimal {
+  out := make([]decimal.Decimal, 0, len(values))
+
+  for _, v := range values {
+    out = append(out, decimal.NewFromInt(v))
+  }
+
+  return out
+}
+
+func TestMovingAverages(t *testing.T) {
+  sma, _ := NewSMA(3, 8)
+  ema, _ := NewEMA(3, 8)
+  wma, _ := NewWMA(3, 8)
+
+  for i, v := range series(2, 4, 6, 8, 13) {
+    sma.Add(v)
+    ema.Add(v)
+    wma.Add(v)
+
+    if ready := i >= 2; sma.Ready() != ready || ema.Ready() != ready || wma.Ready() != ready {
+      t.Fatalf("Expected the averages to become ready after three values (at value %d).", i+1)
+    }
+  }
+
+  //
+  // SMA = (6 + 8 + 13) ÷ 3, EMA is seeded with SMA(2, 4, 6) = 4 and then smoothed by a factor of
+  // 0.5 to 6 and then 9.5, and WMA = (6 + 2 × 8 + 3 × 13) ÷ 6.
+  //
+  if !sma.Value().Equal(decimal.NewFromInt(9)) {
+    t.Errorf("Expected an SMA of 9 but got %s.", sma.Value())
+  }
+
+  if !ema.Value().Equal(decimal.NewFromFloat(9.5)) {
+    t.Errorf("Expected an EMA of 9.5 but got %s.", ema.Value())
+  }
+
+  expected := decimal.NewFromInt(61).Div(decimal.NewFromInt(6)).Round(8)
+  if !wma.Value().Equal(expected) {
+    t.Errorf("Expected a WMA of %s but got %s.", expected, wma.Value())
+  }
+}
+
+func TestRSI(t *testing.T) {
+  rsi, _ := NewRSI(2, 4)
+
+  //
+  // The first two changes (+2, -1) seed average gains and losses of 1 and 0.5. The next change (+3)
+  // smooths them into 2 and 0.25, for an RSI of 100 - 100 ÷ 9.
+  //
+  for _, v := range series(10, 12, 11, 14) {
+    rsi.Add(v)
+  }
+
+  if !rsi.Ready() || !rsi.Value().Equal(decimal.RequireFromString("88.8889")) {
+    t.Errorf("Expected an RSI of 88.8889 but got %s.", rsi.Value())
+  }
+}
+
+func TestMACD(t *testing.T) {
+  macd, _ := NewMACD(2, 3, 2, 8)
+
+  for _, v := range series(1, 2, 3, 4) {
+    macd.Add(v)
+  }
+
+  if !macd.Ready() {
+    t.Fatalf("Expected the MACD to be ready after one less value than its slow and signal periods.")
+  }
+
+  //
+  // On a steady rise, the fast average leads the slow average by the same amount every period.
+  //
+  if !macd.Line().Equal(decimal.NewFromFloat(0.5)) || !macd.Histogram().IsZero() {
+    t.Errorf("Expected a line of 0.5 and a flat histogram but got %s and %s.", macd.Line(), macd.Histogram())
+  }
+}
+
+func TestBollinger(t *testing.T) {
+  bands, _ := NewBollinger(4, decimal.NewFromInt(2), 8)
+
+  for _, v := range series(2, 4, 4, 6) {
+    bands.Add(v)
+  }
+
+  //
+  // The mean is 4 and the (population) standard deviation is √2.
+  //
+  if !bands.Middle().Equal(decimal.NewFromInt(4)) {
+    t.Errorf("Expected a middle band of 4 but got %s.", bands.Middle())
+  }
+
+  if !bands.Upper().Equal(decimal.RequireFromString("6.82842712")) || !bands.Lower().Equal(decimal.RequireFromString("1.17157288")) {
+    t.Errorf("Expected bands of 4 ± 2√2 but got %s and %s.", bands.Lower(), bands.Upper())
+  }
+}
+
+func TestATR(t *testing.T) {
+  atr, _ := NewATR(2, Unrounded)
+
+  //
+  // True ranges are 2, then 4 (from the previous close of 11 up to 15), and then 1, for a seed of 3
+  // that is smoothed into 2.
+  //
+  atr.Add(decimal.NewFromInt(12), decimal.NewFromInt(10), decimal.NewFromInt(11))
+
+  if atr.Ready() {
+    t.Fatalf("Expected the ATR not to be ready after a single candle.")
+  }
+
+  atr.Add(decimal.NewFromInt(15), decimal.NewFromInt(13), decimal.NewFromInt(14))
+  atr.Add(decimal.NewFromInt(15), decimal.NewFromInt(14), decimal.NewFromInt(14))
+
+  if !atr.Value().Equal(decimal.NewFromInt(2)) {
+    t.Errorf("Expected an ATR of 2 but got %s.", atr.Value())
+  }
+}
+
+func TestStochastic(t *testing.T) {
+  stoch, _ := NewStochastic(3, 2, 4)
+
+  add := func(high int64, low int64, close int64) {
+    stoch.Add(decimal.NewFromInt(high), decimal.NewFromInt(low), decimal.NewFromInt(close))
+  }
+
+  //
+  // The first full period spans 8 to 12 and closes at 11 (%K = 75). The next spans 9 to 14 (the 8
+  // having been evicted) and closes at 14 (%K = 100).
+  //
+  add(10, 8, 9)
+  add(12, 9, 10)
+  add(11, 9, 11)
+  add(14, 10, 14)
+
+  if !stoch.Ready() || !stoch.K().Equal(decimal.NewFromInt(100)) || !stoch.D().Equal(decimal.NewFromFloat(87.5)) {
+    t.Errorf("Expected a %%K of 100 and a %%D of 87.5 but got %s and %s.", stoch.K(), stoch.D())
+  }
+}
+
+func TestDonchian(t *testing.T) {
+  channel, _ := NewDonchian(3, 8)
+
+  add := func(high int64, low int64) {
+    channel.Add(decimal.NewFromInt(high), decimal.NewFromInt(low))
+  }
+
+  //
+  // The high of 20 and the low of 5 are both evicted by the time the fourth candle is added.
+  //
+  add(20, 5)
+  add(12, 9)
+  add(11, 8)
+
+  if !channel.Ready() || !channel.Upper().Equal(decimal.NewFromInt(20)) || !channel.Lower().Equal(decimal.NewFromInt(5)) {
+    t.Fatalf("Expected a channel of 5 to 20 but got %s to %s.", channel.Lower(), channel.Upper())
+  }
+
+  add(10, 9)
+
+  if !channel.Upper().Equal(decimal.NewFromInt(12)) || !channel.Lower().Equal(decimal.NewFromInt(8)) {
+    t.Errorf("Expected a channel of 8 to 12 but got %s to %s.", channel.Lower(), channel.Upper())
+  }
+}
+
+func TestVolumeIndicators(t *testing.T) {
+  obv := NewOBV(8)
+  vwap, _ := NewVWAP(2, 8)
+
+  add := func(price int64, volume int64) {
+    p, v := decimal.NewFromInt(price), decimal.NewFromInt(volume)
+
+    obv.Add(p, v)
+    vwap.Add(p, p, p, v)
+  }
+
+  add(10, 5)
+  add(12, 3)
+  add(11, 2)
+  add(11, 7)
+
+  if !obv.Value().Equal(decimal.NewFromInt(1)) {
+    t.Errorf("Expected an OBV of 1 but got %s.", obv.Value())
+  }
+
+  //
+  // Only the two most recent candles are in the rolling window, and they both traded at 11.
+  //
+  if !vwap.Ready() || !vwap.Value().Equal(decimal.NewFromInt(11)) {
+    t.Errorf("Expected a VWAP of 11 but got %s.", vwap.Value())
+  }
+}
@@ -0,0 +1,104 @@
+package indicators
+
+import (
+  "fmt"
+  "github.com/shopspring/decimal"
+)
+
+//
+// MACD is the moving average convergence/divergence of a series of values. Its line is the
+// difference between a fast and a slow exponential moving average of the values, its signal is an
+// exponential moving average of the line, and its histogram is the difference between the two.
+//
+type MACD struct {
+  precision int32
+  fast      *EMA
+  slow      *EMA
+  signal    *EMA
+  line      decimal.Decimal
+  histogram decimal.Decimal
+}
+
+//
+// NewMACD instantiates a new moving average convergence/divergence with the provided fast, slow,
+// and signal periods (commonly 12, 26, and 9), rounded to the provided number of decimals.
+//
+func NewMACD(fastPeriod int, slowPeriod int, signalPeriod int, precision int32) (*MACD, error) {
+  if fastPeriod >= slowPeriod {
+    return nil, fmt.Errorf("the fast MACD period (%d) must be less than the slow period (%d)", fastPeriod, slowPeriod)
+  }
+
+  fast, err := NewEMA(fastPeriod, precision)
+  if err != nil {
+    return nil, err
+  }
+
+  slow, err := NewEMA(slowPeriod, precision)
+  if err != nil {
+    return nil, err
+  }
+
+  signal, err := NewEMA(signalPeriod, precision)
+  if err != nil {
+    return nil, err
+  }
+
+  return &MACD{
+    precision: precision,
+    fast:      fast,
+    slow:      slow,
+    signal:    signal,
+    line:      decimal.Zero,
+    histogram: decimal.Zero,
+  }, nil
+}
+
+//
+// Add updates the indicator with the provided value.
+//
+func (o *MACD) Add(value decimal.Decimal) {
+  o.fast.Add(value)
+  o.slow.Add(value)
+
+  if !o.slow.Ready() {
+    return
+  }
+
+  o.line = o.fast.Value().Sub(o.slow.Value())
+  o.signal.Add(o.line)
+
+  if o.signal.Ready() {
+    o.histogram = round(o.line.Sub(o.signal.Value()), o.precision)
+  }
+}
+
+//
+// Ready returns whether or not enough values have been added for the line, signal, and histogram
+// to all be calculated. This takes one less value than the slow and signal periods combined.
+//
+func (o *MACD) Ready() bool {
+  return o.signal.Ready()
+}
+
+//
+// Line returns the difference between the fast and slow moving averages. Returns zero until the
+// slow moving average is ready.
+//
+func (o *MACD) Line() decimal.Decimal {
+  return o.line
+}
+
+//
+// Signal returns the moving average of the line. Returns zero until the indicator is ready.
+//
+func (o *MACD) Signal() decimal.Decimal {
+  return o.signal.Value()
+}
+
+//
+// Histogram returns the difference between the line and the signal. Returns zero until the
+// indicator is ready.
+//
+func (o *MACD) Histogram() decimal.Decimal {
+  return o.histogram
+}
@@ -0,0 +1,58 @@
+package indicators
+
+import (
+  "github.com/shopspring/decimal"
+)
+
+//
+// OBV is on-balance volume, a running total of volume that is added on candles that close higher
+// than the one before them and subtracted on candles that close lower.
+//
+type OBV struct {
+  precision int32
+  started   bool
+  prevClose decimal.Decimal
+  value     decimal.Decimal
+}
+
+//
+// NewOBV instantiates a new on-balance volume, rounded to the provided number of decimals.
+//
+func NewOBV(precision int32) *OBV {
+  return &OBV{
+    precision: precision,
+    prevClose: decimal.Zero,
+    value:     decimal.Zero,
+  }
+}
+
+//
+// Add updates the on-balance volume with the close and volume of a newly-closed candle.
+//
+func (o *OBV) Add(close decimal.Decimal, volume decimal.Decimal) {
+  if o.started {
+    switch {
+    case close.GreaterThan(o.prevClose):
+      o.value = round(o.value.Add(volume), o.precision)
+    case close.LessThan(o.prevClose):
+      o.value = round(o.value.Sub(volume), o.precision)
+    }
+  }
+
+  o.prevClose = close
+  o.started = true
+}
+
+//
+// Ready returns whether or not any candle has been added. The on-balance volume starts out at zero.
+//
+func (o *OBV) Ready() bool {
+  return o.started
+}
+
+//
+// Value returns the on-balance volume.
+//
+func (o *OBV) Value() decimal.Decimal {
+  return o.value
+}
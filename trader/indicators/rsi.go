@@ -0,0 +1,110 @@
+package indicators
+
+import (
+  "github.com/shopspring/decimal"
+)
+
+//
+// RSI is Wilder's relative strength index, which ranges from 0 to 100. It implements the Series
+// interface.
+//
+// NOTE ~> The average gain and loss are seeded with the simple averages of the first full period of
+//  changes, and every change after that is smoothed into them using Wilder's smoothing.
+//
+type RSI struct {
+  period    decimal.Decimal
+  periodLen int
+  precision int32
+  changes   int
+  prev      decimal.Decimal
+  avgGain   decimal.Decimal
+  avgLoss   decimal.Decimal
+  value     decimal.Decimal
+  started   bool
+}
+
+//
+// NewRSI instantiates a new relative strength index over the provided number of changes, rounded
+// to the provided number of decimals.
+//
+func NewRSI(period int, precision int32) (*RSI, error) {
+  if err := validatePeriod("RSI", period); err != nil {
+    return nil, err
+  }
+
+  return &RSI{
+    period:    decimal.NewFromInt(int64(period)),
+    periodLen: period,
+    precision: precision,
+    prev:      decimal.Zero,
+    avgGain:   decimal.Zero,
+    avgLoss:   decimal.Zero,
+    value:     decimal.Zero,
+  }, nil
+}
+
+//
+// Add implements the Series interface's described method.
+//
+func (o *RSI) Add(value decimal.Decimal) {
+  if !o.started {
+    o.prev = value
+    o.started = true
+
+    return
+  }
+
+  change := value.Sub(o.prev)
+  gain := decimal.Max(change, decimal.Zero)
+  loss := decimal.Max(change.Neg(), decimal.Zero)
+
+  o.prev = value
+  o.changes++
+
+  switch {
+  case o.changes < o.periodLen:
+    o.avgGain = o.avgGain.Add(gain)
+    o.avgLoss = o.avgLoss.Add(loss)
+
+    return
+  case o.changes == o.periodLen:
+    o.avgGain = round(o.avgGain.Add(gain).Div(o.period), o.precision)
+    o.avgLoss = round(o.avgLoss.Add(loss).Div(o.period), o.precision)
+  default:
+    prior := o.period.Sub(one)
+
+    o.avgGain = round(o.avgGain.Mul(prior).Add(gain).Div(o.period), o.precision)
+    o.avgLoss = round(o.avgLoss.Mul(prior).Add(loss).Div(o.period), o.precision)
+  }
+
+  //
+  // NOTE ~> RSI = 100 - 100 ÷ (1 + average gain ÷ average loss). A market that has not moved at all
+  //  is considered to be neutral.
+  //
+  switch {
+  case o.avgLoss.IsZero() && o.avgGain.IsZero():
+    o.value = fifty
+  case o.avgLoss.IsZero():
+    o.value = hundred
+  default:
+    rs := o.avgGain.Div(o.avgLoss)
+
+    o.value = round(hundred.Sub(hundred.Div(rs.Add(one))), o.precision)
+  }
+}
+
+//
+// Ready implements the Series interface's described method. The index is ready once a full period
+// of changes (i.e. one more value than the period) has been added.
+//
+func (o *RSI) Ready() bool {
+  return o.changes >= o.periodLen
+}
+
+//
+// Value implements the Series interface's described method. Returns zero until the index is
+// ready.
+//
+func (o *RSI) Value() decimal.Decimal {
+  return o.value
+}
@@ -0,0 +1,65 @@
+package indicators
+
+import (
+  "github.com/shopspring/decimal"
+)
+
+//
+// SMA is a simple moving average. It implements the Series interface.
+//
+type SMA struct {
+  period    decimal.Decimal
+  precision int32
+  window    *window
+  sum       decimal.Decimal
+  value     decimal.Decimal
+}
+
+//
+// NewSMA instantiates a new simple moving average of the provided number of values, rounded to the
+// provided number of decimals.
+//
+func NewSMA(period int, precision int32) (*SMA, error) {
+  if err := validatePeriod("SMA", period); err != nil {
+    return nil, err
+  }
+
+  return &SMA{
+    period:    decimal.NewFromInt(int64(period)),
+    precision: precision,
+    window:    newWindow(period),
+    sum:       decimal.Zero,
+    value:     decimal.Zero,
+  }, nil
+}
+
+//
+// Add implements the Series interface's described method.
+//
+func (o *SMA) Add(value decimal.Decimal) {
+  o.sum = o.sum.Add(value)
+
+  if evicted, ok := o.window.push(value); ok {
+    o.sum = o.sum.Sub(evicted)
+  }
+
+  if o.Ready() {
+    o.value = round(o.sum.Div(o.period), o.precision)
+  }
+}
+
+//
+// Ready implements the Series interface's described method. The average is ready once a full
+// period of values has been added.
+//
+func (o *SMA) Ready() bool {
+  return o.window.full
+}
+
+//
+// Value implements the Series interface's described method. Returns zero until the average is
+// ready.
+//
+func (o *SMA) Value() decimal.Decimal {
+  return o.value
+}
@@ -0,0 +1,94 @@
+package indicators
+
+import (
+  "github.com/shopspring/decimal"
+)
+
+//
+// Stochastic is the stochastic oscillator, which ranges from 0 to 100. Its %K is where the latest
+// close sits within the range of the most recent candles, and its %D is a simple moving average of
+// %K.
+//
+type Stochastic struct {
+  periodLen int
+  precision int32
+  count     int
+  highest   *extreme
+  lowest    *extreme
+  k         decimal.Decimal
+  d         *SMA
+}
+
+//
+// NewStochastic instantiates a new stochastic oscillator whose %K looks at the provided number of
+// candles (commonly 14) and whose %D averages the provided number of %K values (commonly 3),
+// rounded to the provided number of decimals.
+//
+func NewStochastic(kPeriod int, dPeriod int, precision int32) (*Stochastic, error) {
+  if err := validatePeriod("stochastic %K", kPeriod); err != nil {
+    return nil, err
+  }
+
+  d, err := NewSMA(dPeriod, precision)
+  if err != nil {
+    return nil, err
+  }
+
+  return &Stochastic{
+    periodLen: kPeriod,
+    precision: precision,
+    highest:   newExtreme(kPeriod, true),
+    lowest:    newExtreme(kPeriod, false),
+    k:         decimal.Zero,
+    d:         d,
+  }, nil
+}
+
+//
+// Add updates the oscillator with the high, low, and close of a newly-closed candle.
+//
+func (o *Stochastic) Add(high decimal.Decimal, low decimal.Decimal, close decimal.Decimal) {
+  o.highest.push(high)
+  o.lowest.push(low)
+  o.count++
+
+  if o.count < o.periodLen {
+    return
+  }
+
+  //
+  // NOTE ~> %K = (close - lowest low) ÷ (highest high - lowest low) × 100. A market that has not
+  //  moved at all is considered to be in the middle of its range.
+  //
+  hh := o.highest.value()
+  ll := o.lowest.value()
+
+  if hh.Equal(ll) {
+    o.k = fifty
+  } else {
+    o.k = round(close.Sub(ll).Div(hh.Sub(ll)).Mul(hundred), o.precision)
+  }
+
+  o.d.Add(o.k)
+}
+
+//
+// Ready returns whether or not enough candles have been added for both %K and %D to be calculated.
+//
+func (o *Stochastic) Ready() bool {
+  return o.d.Ready()
+}
+
+//
+// K returns %K. Returns zero until a full period of candles has been added.
+//
+func (o *Stochastic) K() decimal.Decimal {
+  return o.k
+}
+
+//
+// D returns %D. Returns zero until the oscillator is ready.
+//
+func (o *Stochastic) D() decimal.Decimal {
+  return o.d.Value()
+}
@@ -0,0 +1,98 @@
+package indicators
+
+import (
+  "fmt"
+  "github.com/shopspring/decimal"
+)
+
+//
+// VWAP is the volume-weighted average price of a market, using the typical price (the average of
+// the high, low, and close) of each candle. It is either cumulative (since it was last reset, e.g.
+// at the start of each session) or rolling over a fixed number of candles.
+//
+type VWAP struct {
+  precision int32
+  values    *window // The typical price multiplied by the volume of each candle (if rolling).
+  volumes   *window // The volume of each candle (if rolling).
+  sum       decimal.Decimal
+  volume    decimal.Decimal
+  value     decimal.Decimal
+}
+
+//
+// NewVWAP instantiates a new volume-weighted average price over the provided number of candles (or
+// cumulatively if the period is zero), rounded to the provided number of decimals.
+//
+func NewVWAP(period int, precision int32) (*VWAP, error) {
+  if period < 0 {
+    return nil, fmt.Errorf("the VWAP period (%d) must not be negative", period)
+  }
+
+  o := &VWAP{precision: precision}
+
+  if period > 0 {
+    o.values = newWindow(period)
+    o.volumes = newWindow(period)
+  }
+
+  o.Reset()
+
+  return o, nil
+}
+
+//
+// Add updates the average with the high, low, close, and volume of a newly-closed candle.
+//
+func (o *VWAP) Add(high decimal.Decimal, low decimal.Decimal, close decimal.Decimal, volume decimal.Decimal) {
+  value := high.Add(low).Add(close).Div(three).Mul(volume)
+
+  o.sum = o.sum.Add(value)
+  o.volume = o.volume.Add(volume)
+
+  if o.values != nil {
+    if evicted, ok := o.values.push(value); ok {
+      o.sum = o.sum.Sub(evicted)
+    }
+
+    if evicted, ok := o.volumes.push(volume); ok {
+      o.volume = o.volume.Sub(evicted)
+    }
+  }
+
+  if o.Ready() {
+    o.value = round(o.sum.Div(o.volume), o.precision)
+  }
+}
+
+//
+// Reset clears everything that has been added to the average (e.g. at the start of a new session).
+//
+func (o *VWAP) Reset() {
+  if o.values != nil {
+    o.values = newWindow(len(o.values.values))
+    o.volumes = newWindow(len(o.volumes.values))
+  }
+
+  o.sum = decimal.Zero
+  o.volume = decimal.Zero
+  o.value = decimal.Zero
+}
+
+//
+// Ready returns whether or not the average has been calculated. This requires some volume to have
+// been traded and, if rolling, a full period of candles to have been added.
+//
+func (o *VWAP) Ready() bool {
+  if o.values != nil && !o.values.full {
+    return false
+  }
+
+  return o.volume.GreaterThan(decimal.Zero)
+}
+
+//
+// Value returns the average. Returns zero until the average is ready.
+//
+func (o *VWAP) Value() decimal.Decimal {
+  return o.value
+}
@@ -0,0 +1,79 @@
+package indicators
+
+import (
+  "github.com/shopspring/decimal"
+)
+
+//
+// WMA is a linearly-weighted moving average, in which the most recent value has a weight equal to
+// the period and the oldest value has a weight of one. It implements the Series interface.
+//
+type WMA struct {
+  period    decimal.Decimal
+  weights   decimal.Decimal // The sum of the weights of a full period of values.
+  precision int32
+  window    *window
+  count     int
+  sum       decimal.Decimal // The unweighted sum of the values in the window.
+  weighted  decimal.Decimal // The weighted sum of the values in the window.
+  value     decimal.Decimal
+}
+
+//
+// NewWMA instantiates a new weighted moving average of the provided number of values, rounded to
+// the provided number of decimals.
+//
+func NewWMA(period int, precision int32) (*WMA, error) {
+  if err := validatePeriod("WMA", period); err != nil {
+    return nil, err
+  }
+
+  return &WMA{
+    period:    decimal.NewFromInt(int64(period)),
+    weights:   decimal.NewFromInt(int64(period * (period + 1) / 2)),
+    precision: precision,
+    window:    newWindow(period),
+    sum:       decimal.Zero,
+    weighted:  decimal.Zero,
+    value:     decimal.Zero,
+  }, nil
+}
+
+//
+// Add implements the Series interface's described method.
+//
+// NOTE ~> Once the window is full, adding a value shifts every other value's weight down by one,
+//  which is the same as subtracting the unweighted sum of the window from the weighted sum.
+//
+func (o *WMA) Add(value decimal.Decimal) {
+  evicted, full := o.window.push(value)
+
+  if full {
+    o.weighted = o.weighted.Sub(o.sum).Add(value.Mul(o.period))
+    o.sum = o.sum.Sub(evicted).Add(value)
+  } else {
+    o.count++
+    o.weighted = o.weighted.Add(value.Mul(decimal.NewFromInt(int64(o.count))))
+    o.sum = o.sum.Add(value)
+  }
+
+  if o.Ready() {
+    o.value = round(o.weighted.Div(o.weights), o.precision)
+  }
+}
+
+//
+// Ready implements the Series interface's described method. The average is ready once a full
+// period of values has been added.
+//
+func (o *WMA) Ready() bool {
+  return o.window.full
+}
+
+//
+// Value implements the Series interface's described method. Returns zero until the average is
+// ready.
+//
+func (o *WMA) Value() decimal.Decimal {
+  return o.value
+}
@@ -1,23 +1,26 @@
 package monitor
 
 import (
+  "errors"
   "flag"
   "fmt"
   "github.com/lukehollenback/goose/constants"
   "github.com/lukehollenback/goose/exchange"
+  "github.com/lukehollenback/goose/exchange/file"
+  "github.com/lukehollenback/goose/trader/broker"
   "github.com/lukehollenback/goose/trader/candle"
   "github.com/lukehollenback/goose/trader/writer"
   "github.com/shopspring/decimal"
   "log"
+  "math/rand"
+  "sort"
   "sync"
   "time"
-
-  ws "github.com/gorilla/websocket"
-  coinbasepro "github.com/preichenberger/go-coinbasepro/v2"
 )
 
 const (
-  Name = "≪monitor-service≫"
+  Name       = "≪monitor-service≫"
+  QuoteAsset = "USD"
 )
 
 var (
@@ -28,6 +31,10 @@ var (
   cfgBacktest      *bool
   cfgBacktestStart *string
   cfgBacktestEnd   *string
+  cfgBacktestData  *string
+  cfgReconnectMin  *time.Duration
+  cfgReconnectMax  *time.Duration
+  cfgFeedTimeout   *time.Duration
 )
 
 func init() {
@@ -57,6 +64,33 @@ func init() {
     "2006-01-02 03:04",
     "The desired backtest end timestamp.",
   )
+
+  cfgBacktestData = flag.String(
+    "backtest-data",
+    "",
+    "A local CSV or JSON-lines candle file (or a directory of them laid out as <symbol>/<interval>.csv) to "+
+        "backtest against instead of the exchange's REST API.",
+  )
+
+  cfgReconnectMin = flag.Duration(
+    "reconnect-min",
+    1*time.Second,
+    "The delay before the first attempt to reconnect to a failed websocket feed. Each subsequent attempt "+
+        "doubles the delay.",
+  )
+
+  cfgReconnectMax = flag.Duration(
+    "reconnect-max",
+    1*time.Minute,
+    "The maximum delay between attempts to reconnect to a failed websocket feed.",
+  )
+
+  cfgFeedTimeout = flag.Duration(
+    "feed-timeout",
+    30*time.Second,
+    "How long the websocket feed may go without providing a heartbeat or trade for a market before the "+
+        "connection is considered dead and new entries are paused.",
+  )
 }
 
 //
@@ -67,22 +101,40 @@ type Service struct {
   chKill    chan bool
   chStopped chan bool
 
-  client exchange.Client
+  client   exchange.Client
+  wsClient exchange.WSClient
+  source   exchange.CandleSource // Where historical candles are loaded from. Defaults to the REST API client.
+  data     exchange.CandleSource // Local candle data to backtest against. Takes precedence over all other sources.
 
   backtest      bool
   backtestStart time.Time
   backtestEnd   time.Time
 
-  state state
-  conn  *ws.Conn
+  state        state
+  reconnectMin time.Duration
+  reconnectMax time.Duration
+  feedTimeout  time.Duration
 
-  asset  string
-  market string
+  lastHeartbeats map[string]time.Time // When a heartbeat was last received for each market (local time).
+  lastTrades     map[string]time.Time // When a trade was last received for each market (local time).
 
-  onOneMinCandleCloseHandlers     []func(*candle.Candle)
-  onFiveMinCandleCloseHandlers    []func(*candle.Candle)
-  onFifteenMinCandleCloseHandlers []func(*candle.Candle)
-  onCandleCloseHandlers           []func()
+  assets       []string          // The assets being watched, in the order that they were configured.
+  markets      map[string]string // The symbol of each asset's market as expected by the REST API client.
+  feedMarkets  map[string]string // The symbol of each asset's market as expected by the websocket feed client.
+  feedAssets   map[string]string // The asset of each market symbol as provided by the websocket feed client.
+  readyMarkets map[string]bool   // Whether or not each asset's candles are trustworthy over the current connection.
+
+  subscriptions              []*Subscription
+  onCandleCloseHandlers      []func()
+  onBacktestCompleteHandlers []func()
+}
+
+//
+// assetCandle pairs a historical candle with the asset whose market it was retrieved from.
+//
+type assetCandle struct {
+  asset  string
+  candle exchange.Candle
 }
 
 //
@@ -100,28 +152,48 @@ func Instance() *Service {
 
       backtest: *cfgBacktest,
 
-      state: disconnected,
+      state:        disconnected,
+      reconnectMin: *cfgReconnectMin,
+      reconnectMax: *cfgReconnectMax,
+      feedTimeout:  *cfgFeedTimeout,
+
+      lastHeartbeats: make(map[string]time.Time),
+      lastTrades:     make(map[string]time.Time),
+
+      markets:      make(map[string]string),
+      feedMarkets:  make(map[string]string),
+      feedAssets:   make(map[string]string),
+      readyMarkets: make(map[string]bool),
+
+      subscriptions:         make([]*Subscription, 0),
+      onCandleCloseHandlers: make([]func(), 0),
 
-      onOneMinCandleCloseHandlers:     make([]func(*candle.Candle), 0),
-      onFiveMinCandleCloseHandlers:    make([]func(*candle.Candle), 0),
-      onFifteenMinCandleCloseHandlers: make([]func(*candle.Candle), 0),
-      onCandleCloseHandlers:           make([]func(), 0),
+      onBacktestCompleteHandlers: make([]func(), 0),
     }
 
     //
     // Parse the backtest start and end timestamps if backtesting has been enabled.
     //
     if o.backtest {
-      o.backtestStart, err = time.Parse("2006-01-02 03:04", *cfgBacktestStart)
+      o.backtestStart, err = time.Parse(constants.TimestampFmt, *cfgBacktestStart)
       if err != nil {
         logger.Fatalf("Failed to instantiate. Backtest start timestamp could not be parsed. (Error: %s)", err)
       }
 
-      o.backtestEnd, err = time.Parse("2006-01-02 03:04", *cfgBacktestEnd)
+      o.backtestEnd, err = time.Parse(constants.TimestampFmt, *cfgBacktestEnd)
       if err != nil {
         logger.Fatalf("Failed to instantiate. Backtest end timestamp could not be parsed. (Error: %s)", err)
       }
 
+      if *cfgBacktestData != "" {
+        o.data, err = file.NewCandleSource(*cfgBacktestData)
+        if err != nil {
+          logger.Fatalf("Failed to instantiate. Backtest data could not be opened. (Error: %s)", err)
+        }
+
+        logger.Printf("Backtesting against local candle data. (Path: %s)", *cfgBacktestData)
+      }
+
       logger.Printf("Enabled backtesting. (Start: %s, End: %s)", o.backtestStart, o.backtestEnd)
     }
   })
@@ -130,52 +202,99 @@ func Instance() *Service {
 }
 
 //
-// SetAsset tells the Monitor Service which asset it should subscribe to and watch.
+// SetAssets tells the Monitor Service which assets it should subscribe to and watch. The markets of
+// all of the assets are watched over a single websocket connection. This should be called before
+// any handlers subscribe to the assets' candles.
 //
-func (o *Service) SetAsset(asset string) {
-  o.asset = asset
-  o.market = o.client.RetrieveSymbol(asset, "USD")
+func (o *Service) SetAssets(assets []string) {
+  o.mu.Lock()
+  defer o.mu.Unlock()
+
+  o.assets = append([]string{}, assets...)
+  o.markets = make(map[string]string)
+  o.feedMarkets = make(map[string]string)
+  o.feedAssets = make(map[string]string)
+
+  for _, asset := range assets {
+    if source := o.candleSource(); source != nil {
+      o.markets[asset] = source.RetrieveSymbol(asset, QuoteAsset)
+    }
+
+    if o.wsClient != nil {
+      feedMarket := o.wsClient.RetrieveSymbol(asset, QuoteAsset)
+
+      o.feedMarkets[asset] = feedMarket
+      o.feedAssets[feedMarket] = asset
+    }
+  }
+}
+
+//
+// Assets returns the assets that the Monitor Service is watching.
+//
+func (o *Service) Assets() []string {
+  o.mu.Lock()
+  defer o.mu.Unlock()
+
+  return append([]string{}, o.assets...)
+}
+
+//
+// watching returns whether or not the Monitor Service is watching the provided asset.
+//
+func (o *Service) watching(asset string) bool {
+  for _, v := range o.assets {
+    if v == asset {
+      return true
+    }
+  }
+
+  return false
 }
 
 //
 // SetClient tells the Monitor Service which client instance it should use to communicate with the
-// relevant exchange's REST API (e.g. for loading historical data).
+// relevant exchange's REST API (e.g. for loading historical data). This should be called before the
+// asset is set.
 //
 func (o *Service) SetClient(client exchange.Client) {
   o.client = client
 }
 
 //
-// RegisterOneMinCandleCloseHandler registers a signal handler to be executed whenever a one minute
-// candle closes out.
+// SetCandleSource tells the Monitor Service where it should load historical candles from when
+// backtesting, overriding the REST API client (but not any local backtest data that has been
+// configured). This should be called before the asset is set.
 //
-func (o *Service) RegisterOneMinCandleCloseHandler(handler func(*candle.Candle)) {
-  o.mu.Lock()
-  defer o.mu.Unlock()
-
-  o.onOneMinCandleCloseHandlers = append(o.onOneMinCandleCloseHandlers, handler)
+func (o *Service) SetCandleSource(source exchange.CandleSource) {
+  o.source = source
 }
 
 //
-// RegisterFiveMinCandleCloseHandler registers a signal handler to be executed whenever a five
-// minute candle closes out.
+// candleSource returns where historical candles should be loaded from.
 //
-func (o *Service) RegisterFiveMinCandleCloseHandler(handler func(*candle.Candle)) {
-  o.mu.Lock()
-  defer o.mu.Unlock()
+func (o *Service) candleSource() exchange.CandleSource {
+  if o.data != nil {
+    return o.data
+  }
+
+  if o.source != nil {
+    return o.source
+  }
+
+  if o.client != nil {
+    return o.client
+  }
 
-  o.onFiveMinCandleCloseHandlers = append(o.onFiveMinCandleCloseHandlers, handler)
+  return nil
 }
 
 //
-// RegisterFifteenMinCandleCloseHandler registers a signal handler to be executed whenever a
-// fifteen minute candle closes out.
+// SetWSClient tells the Monitor Service which client instance it should use to monitor the relevant
+// exchange's live websocket feed. This should be called before the asset is set.
 //
-func (o *Service) RegisterFifteenMinCandleCloseHandler(handler func(*candle.Candle)) {
-  o.mu.Lock()
-  defer o.mu.Unlock()
-
-  o.onFifteenMinCandleCloseHandlers = append(o.onFifteenMinCandleCloseHandlers, handler)
+func (o *Service) SetWSClient(wsClient exchange.WSClient) {
+  o.wsClient = wsClient
 }
 
 //
@@ -189,6 +308,25 @@ func (o *Service) RegisterCandleCloseHandler(handler func()) {
   o.onCandleCloseHandlers = append(o.onCandleCloseHandlers, handler)
 }
 
+//
+// RegisterBacktestCompleteHandler registers a handler to be executed once every historical candle
+// has been produced during a backtest (e.g. so that the results of the backtest can be reported).
+//
+func (o *Service) RegisterBacktestCompleteHandler(handler func()) {
+  o.mu.Lock()
+  defer o.mu.Unlock()
+
+  o.onBacktestCompleteHandlers = append(o.onBacktestCompleteHandlers, handler)
+}
+
+//
+// Backtesting returns whether or not the Monitor Service is producing historical candles rather
+// than monitoring the live market.
+//
+func (o *Service) Backtesting() bool {
+  return o.backtest
+}
+
 //
 // Start implements the Service interface's described method.
 //
@@ -199,7 +337,25 @@ func (o *Service) Start() (<-chan bool, error) {
   //
   // Validate that necessary configurations have been provided.
   //
-  // TODO ~> This.
+  if o.backtest && o.candleSource() == nil {
+    return nil, errors.New("a client or candle source must be provided in order to backtest")
+  }
+
+  if len(o.assets) == 0 {
+    return nil, errors.New("at least one asset must be provided to monitor")
+  }
+
+  if !o.backtest && o.wsClient == nil {
+    return nil, errors.New("a websocket feed client must be provided in order to monitor live trades")
+  }
+
+  if o.reconnectMin <= 0 || o.reconnectMax < o.reconnectMin {
+    return nil, errors.New("reconnect delays must be positive and the maximum must not be less than the minimum")
+  }
+
+  if o.feedTimeout <= 0 {
+    return nil, errors.New("the feed timeout must be positive")
+  }
 
   //
   // (Re)initialize our instance variables.
@@ -248,7 +404,7 @@ func (o *Service) Stop() (<-chan bool, error) {
 }
 
 //
-// service connects to the Coinbase Pro websocket feed and monitors it for trade events so that it
+// service connects to the relevant exchange's websocket feed and monitors it for trade events so that it
 // can determine when to buy or sell currency.
 //
 func (o *Service) service() {
@@ -268,13 +424,15 @@ func (o *Service) service() {
 }
 
 //
-// backtestTrades loads historical handles from the relevant exchange API and converts them directly
-// into candles that it can produce.
+// backtestTrades loads historical candles from the configured candle source (normally the relevant
+// exchange's API) and converts them directly into candles that it can produce.
 //
 func (o *Service) backtestTrades() {
+  source := o.candleSource()
+
   //
-  // Retrieve, process, and produce historical candles from the relevant exchange's API for the
-  // configured backtest period.
+  // Retrieve, process, and produce historical candles from the candle source for the configured
+  // backtest period.
   //
   for s, e, c := o.obtainBacktestCursors(nil); c; s, e, c = o.obtainBacktestCursors(s) {
     //
@@ -283,69 +441,62 @@ func (o *Service) backtestTrades() {
     logger.Printf("Loading historical candles from %s through %s.", s, e)
 
     //
-    // Load historical candles.
+    // Load historical one minute candles for each market. Candles of every other interval are built
+    // up from them by the Candle Store Service.
     //
-    oneMinResp, err := o.client.RetrieveCandles(o.market, exchange.OneMinute, *s, *e, 1000)
-    if err != nil {
-      logger.Fatalf("Failed to load historical one minute candles. (Error: %s)", err)
-    }
+    oneMinCandles := make([]assetCandle, 0)
 
-    fiveMinResp, err := o.client.RetrieveCandles(o.market, exchange.FiveMinute, *s, *e, 1000)
-    if err != nil {
-      logger.Fatalf("Failed to load historical five minute candles. (Error: %s)", err)
-    }
+    for _, asset := range o.assets {
+      oneMinResp, err := source.RetrieveCandles(o.markets[asset], exchange.OneMinute, *s, *e, 1000)
+      if err != nil {
+        logger.Fatalf("Failed to load historical one minute candles. (Market: %s) (Error: %s)", o.markets[asset], err)
+      }
+
+      //
+      // Log some debug info.
+      //
+      logger.Printf("Loaded %d one minute candles. (Market: %s)", len(oneMinResp.Candles()), o.markets[asset])
 
-    fifteenMinResp, err := o.client.RetrieveCandles(o.market, exchange.FifteenMinute, *s, *e, 1000)
-    if err != nil {
-      logger.Fatalf("Failed to load historical fifteen minute candles. (Error: %s)", err)
+      for _, v := range oneMinResp.Candles() {
+        oneMinCandles = append(oneMinCandles, assetCandle{asset: asset, candle: v})
+      }
     }
 
     //
-    // Log some debug info.
+    // Interleave the candles of all markets so that they are produced in the order in which they
+    // would have closed out live.
     //
-    logger.Printf(
-      "Loaded %d one minute, %d five minute, and %d fifteen minute candles.",
-      len(oneMinResp.Candles()), len(fiveMinResp.Candles()), len(fifteenMinResp.Candles()),
-    )
+    sort.SliceStable(oneMinCandles, func(i, j int) bool {
+      return oneMinCandles[i].candle.StartTime().Before(*(oneMinCandles[j].candle.StartTime()))
+    })
 
     //
     // Process and produce historical candles.
     //
-    fiveMinIndex := 0
-    fifteenMinIndex := 0
-
-    for _, v := range oneMinResp.Candles() {
+    for _, v := range oneMinCandles {
       //
       // Write the one minute candle's close price.
       //
-      _ = writer.Instance().Write(*(v.EndTime()), writer.ClosingPrice, *(v.Close()))
+      _ = writer.Instance().WriteFor(*(v.candle.EndTime()), v.asset, writer.ClosingPrice, *(v.candle.Close()))
 
       //
-      // Create all of the necessary candles and then "produce" them to any handlers that are
-      // registered and waiting for them.
+      // Fold the one minute candle into all of the necessary candles and then "produce" any that
+      // closed out to the handlers that are registered and waiting for them.
       //
-      // NOTE ~> We will always have a one minute candle. Occasionally we'll have a five minute
-      //  candle or a fifteen minute candle.
-      //
-      candles := &candle.Candles{
-        OneMin: candle.CreateFullCandle(*(v.StartTime()), candle.OneMin, *(v.Open()), *(v.Close()), *(v.High()), *(v.Low()), *(v.Volume()), decimal.NewFromInt(int64(*(v.Count())))),
-      }
-
-      if fiveMinResp.Candles()[fiveMinIndex].EndTime().Equal(*v.EndTime()) {
-        v := fiveMinResp.Candles()[fiveMinIndex]
-        candles.FiveMin = candle.CreateFullCandle(*(v.StartTime()), candle.OneMin, *(v.Open()), *(v.Close()), *(v.High()), *(v.Low()), *(v.Volume()), decimal.NewFromInt(int64(*(v.Count()))))
-
-        fiveMinIndex++
-      }
+      oneMinCandle := candle.CreateFullCandle(*(v.candle.StartTime()), candle.OneMin, *(v.candle.Open()), *(v.candle.Close()), *(v.candle.High()), *(v.candle.Low()), *(v.candle.Volume()), decimal.NewFromInt(int64(*(v.candle.Count()))))
 
-      if fifteenMinResp.Candles()[fifteenMinIndex].EndTime().Equal(*v.EndTime()) {
-        v := fifteenMinResp.Candles()[fifteenMinIndex]
-        candles.FifteenMin = candle.CreateFullCandle(*(v.StartTime()), candle.OneMin, *(v.Open()), *(v.Close()), *(v.High()), *(v.Low()), *(v.Volume()), decimal.NewFromInt(int64(*(v.Count()))))
+      //
+      // Let the Broker Service fill mock orders and evaluate protective exits against the candle
+      // before any strategies see it close.
+      //
+      broker.Instance().MarkCandle(v.asset, v.candle)
 
-        fifteenMinIndex++
+      candles, err := candle.Instance().AppendCandle(v.asset, oneMinCandle)
+      if err != nil {
+        logger.Fatalf("Failed to provide the historical candle to the Candle Store Service. (Error: %s)", err)
       }
 
-      o.processClosedCandles(candles)
+      o.processClosedCandles(v.asset, candles)
     }
   }
 
@@ -353,6 +504,10 @@ func (o *Service) backtestTrades() {
   // Log some debug info.
   //
   logger.Printf("Backtesting has completed.")
+
+  for _, handler := range o.onBacktestCompleteHandlers {
+    handler()
+  }
 }
 
 //
@@ -392,206 +547,337 @@ func (o *Service) obtainBacktestCursors(prevStart *time.Time) (*time.Time, *time
 
 //
 // monitorLiveTrades actually monitors trades as received from the relevant exchange's websocket
-// feed in realtime to produce candles.
+// feed in realtime to produce candles. Whenever the connection fails, it is re-established (and
+// re-subscribed to) after an exponentially-increasing, jittered delay.
 //
 func (o *Service) monitorLiveTrades() {
-  var err error
+  attempt := 0
 
-  //
-  // Connect to the Coinbase Pro websocket feed so that we can monitor network events that occur.
-  //
-  var wsDialer ws.Dialer
+  for {
+    //
+    // (Re)connect to the websocket feed and consume events from it until either the connection
+    // fails or we are told to shut down.
+    //
+    killed := false
+
+    if err := o.connect(); err != nil {
+      logger.Printf("Could not connect and subscribe to the websocket feed. (Error: %s)", err)
+    } else {
+      attempt = 0
+      killed = o.consumeEvents(o.wsClient.Events())
+    }
+
+    //
+    // Close our websocket connection.
+    //
+    if err := o.wsClient.Close(); err != nil {
+      logger.Printf("Failed to close websocket connection. (Error: %s)", err)
+    }
+
+    o.state = disconnected
+
+    if killed {
+      return
+    }
+
+    //
+    // Until we are fully reconnected, the signals that algorithms derive from our candles cannot be
+    // trusted.
+    //
+    broker.Instance().Pause("lost connection to the websocket feed")
+
+    //
+    // Wait a bit before trying again so that we do not hammer the exchange while it (or our
+    // network) is having issues.
+    //
+    delay := o.reconnectDelay(attempt)
+    attempt++
 
+    logger.Printf("Reconnecting to the websocket feed in %s (attempt %d)...", delay, attempt)
+
+    select {
+    case <-o.chKill:
+      return
+
+    case <-time.After(delay):
+    }
+  }
+}
+
+//
+// connect establishes a connection to the websocket feed and subscribes to heartbeat and trade
+// messages for every market over it.
+//
+func (o *Service) connect() error {
   o.state = connecting
+  o.readyMarkets = make(map[string]bool)
 
-  o.conn, _, err = wsDialer.Dial("wss://ws-feed.pro.coinbase.com", nil)
-  if err != nil {
-    log.Fatalf("Could not connect to the Coinbase Pro websocket feed. (Error: %s)", err.Error())
+  if err := o.wsClient.Connect(); err != nil {
+    return err
   }
 
   o.state = connected
 
-  //
-  // Subscribe to heartbeat messages and trade messages over the Coinbase Pro websocket feed.
-  //
-  subscribe := coinbasepro.Message{
-    Type: "subscribe",
-    Channels: []coinbasepro.MessageChannel{
-      coinbasepro.MessageChannel{
-        Name: "heartbeat",
-        ProductIds: []string{
-          o.market,
-        },
-      },
-      coinbasepro.MessageChannel{
-        Name: "matches",
-        ProductIds: []string{
-          o.market,
-        },
-      },
-    },
-  }
+  for _, asset := range o.assets {
+    feedMarket := o.feedMarkets[asset]
 
-  if err := o.conn.WriteJSON(subscribe); err != nil {
-    log.Fatalf(
-      "Could not subscribe to specific messages from the Coinbase Pro websocket feed. (Error: %s)",
-      err.Error(),
-    )
-  }
+    //
+    // Give the new connection a full timeout's worth of time to start providing heartbeats and
+    // trades before the watchdog considers it dead.
+    //
+    now := time.Now()
 
-  //
-  // Begin monitoring and processing messages from the Coinbase Pro websocket feed.
-  //
-  cont := true
+    o.lastHeartbeats[feedMarket] = now
+    o.lastTrades[feedMarket] = now
 
-  for cont {
-    chMsg := make(chan *coinbasepro.Message, 1)
-    chErr := make(chan error, 1)
+    if err := o.wsClient.Subscribe(feedMarket, exchange.HeartbeatChannel, exchange.TradeChannel); err != nil {
+      return err
+    }
+  }
 
-    go o.readNextMessage(chMsg, chErr)
+  return nil
+}
 
+//
+// consumeEvents processes events from the provided event stream until either the stream fails, the
+// stream goes silent for longer than the feed timeout, or the service is told to shut down. Returns
+// true if the service was told to shut down.
+//
+func (o *Service) consumeEvents(chEvents <-chan *exchange.Event) bool {
+  watchdog := time.NewTicker(o.feedTimeout / 4)
+  defer watchdog.Stop()
+
+  for {
     select {
     case <-o.chKill:
-      cont = false
+      return true
 
-      break
+    case now := <-watchdog.C:
+      //
+      // Make sure that the feed has not gone silent. Half-open connections do not necessarily
+      // produce errors, so this is the only way to detect them.
+      //
+      if market, silence := o.silentMarket(now); market != "" {
+        logger.Printf(
+          "The websocket feed has been silent for %s (Market: %s). Considering the connection dead.",
+          silence.Round(time.Millisecond), market,
+        )
 
-    case msg := <-chMsg:
-      o.handleMessage(msg)
+        return false
+      }
+
+    case event, ok := <-chEvents:
+      if !ok {
+        logger.Printf("The websocket feed's event stream closed unexpectedly.")
+
+        return false
+      }
 
-      break
+      if event.Type == exchange.ErrorEvent {
+        logger.Printf("Could not read the next event from the websocket feed. (Error: %s)", event.Err)
 
-    case err := <-chErr:
-      log.Fatalf(
-        "Could not read the next JSON message from the Coinbase Pro websocket feed. (Error: %s)",
-        err.Error(),
-      )
+        return false
+      }
+
+      o.recordActivity(event)
+      o.handleEvent(event)
     }
   }
+}
 
-  //
-  // Close our websocket connection.
-  //
-  err = o.conn.Close()
-  if err != nil {
-    log.Fatalf("Failed to close websocket connection to Coinbase Pro. (Error: %s)", err)
+//
+// recordActivity tracks when heartbeats and trades were last received for each market so that the
+// watchdog can tell when the feed has gone silent.
+//
+// NOTE ~> Local time is used rather than the exchange's timestamps so that clock skew between us and
+//  the exchange cannot trip (or hide) a timeout.
+//
+func (o *Service) recordActivity(event *exchange.Event) {
+  now := time.Now()
+
+  if event.Type == exchange.HeartbeatEvent {
+    //
+    // Some feeds provide heartbeats for the connection as a whole rather than for specific markets.
+    //
+    if event.Symbol == "" {
+      for market := range o.lastHeartbeats {
+        o.lastHeartbeats[market] = now
+      }
+    } else {
+      o.lastHeartbeats[event.Symbol] = now
+    }
+  } else if event.Type == exchange.TradeEvent {
+    o.lastTrades[event.Symbol] = now
   }
+}
 
-  o.state = disconnected
+//
+// silentMarket returns the first market that has not received a heartbeat or a trade within the
+// feed timeout, along with how long it has been silent. If no market has gone silent, an empty
+// string is returned.
+//
+func (o *Service) silentMarket(now time.Time) (string, time.Duration) {
+  for market, lastHeartbeat := range o.lastHeartbeats {
+    lastActivity := lastHeartbeat
+
+    if lastTrade := o.lastTrades[market]; lastTrade.After(lastActivity) {
+      lastActivity = lastTrade
+    }
+
+    if silence := now.Sub(lastActivity); silence > o.feedTimeout {
+      return market, silence
+    }
+  }
+
+  return "", 0
 }
 
-func (o *Service) readNextMessage(chMsg chan<- *coinbasepro.Message, chErr chan<- error) {
-  msg := &coinbasepro.Message{}
+//
+// reconnectDelay determines how long to wait before making the specified reconnection attempt. The
+// delay doubles with each attempt (up to the configured maximum), and is then jittered down by up
+// to half so that many clients that were disconnected at once do not all reconnect at once.
+//
+func (o *Service) reconnectDelay(attempt int) time.Duration {
+  delay := o.reconnectMin
 
-  if err := o.conn.ReadJSON(msg); err != nil {
-    chErr <- err
+  for i := 0; i < attempt && delay < o.reconnectMax; i++ {
+    delay *= 2
   }
 
-  chMsg <- msg
+  if delay > o.reconnectMax {
+    delay = o.reconnectMax
+  }
+
+  return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
 }
 
-func (o *Service) handleMessage(msg *coinbasepro.Message) {
+//
+// handleEvent moves the Monitor Service through its states and feeds trades to the Candle Service
+// as events are received from the websocket feed.
+//
+func (o *Service) handleEvent(event *exchange.Event) {
   if o.state == connected {
-    if msg.Type == "subscriptions" {
+    if event.Type == exchange.SubscribedEvent {
       //
       // Move the trade monitor service into a "subscribed" state – indicating that it has
-      // successfully received acknowledgement from the Coinbase Pro websocket API that it has
-      // subscribed to the necessary message channnels.
+      // successfully received acknowledgement from the websocket feed that it has subscribed to the
+      // necessary message channels.
       //
       o.state = subscribed
 
-      logger.Printf("Successfully subscribed to relevant Coinbase Pro websocket channels (Market: %s).", o.market)
+      logger.Printf("Successfully subscribed to relevant websocket feed channels (Markets: %v).", o.assets)
     }
-  } else if o.state == subscribed {
-    if msg.Type == "last_match" {
-      //
-      // Extract the trade time and price from the message.
-      //
-      time := msg.Time.Time()
-      amt, err := decimal.NewFromString(msg.Price)
-      if err != nil {
-        log.Fatalf("Failed to parse price from message. (Message: %+v) (Error: %s)", msg, err)
+  } else if o.state == subscribed || o.state == ready {
+    if event.Type == exchange.TradeEvent {
+      asset, ok := o.feedAssets[event.Symbol]
+      if !ok {
+        return
       }
 
-      //
-      // Initialize the Candle Store Service with the last trade as stated by the message.
-      //
-      oneMinCandle := candle.CreateCandle(time, candle.OneMin, amt)
-      fiveMinCandle := candle.CreateCandle(time, candle.FiveMin, amt)
-      fifteenMinCandle := candle.CreateCandle(time, candle.FifteenMin, amt)
-
-      if err := candle.Instance().Init(oneMinCandle, fiveMinCandle, fifteenMinCandle); err != nil {
-        log.Fatalf("Failed to initialize the Candle Store Service. (Error: %s)", err)
+      if o.readyMarkets[asset] {
+        o.appendTrade(asset, event)
+      } else {
+        o.prepareMarket(asset, event)
       }
+    }
+  }
+}
 
-      //
-      // Move the Trade Monitor Service into a "ready" state – indicating that it is now fully ready
-      // to begin monitoring and processing trades received from the Coinbase Pro websocket API.
-      //
-      o.state = ready
+//
+// prepareMarket uses the first trade that has been received for the specified asset's market over
+// the current connection to make the asset's candles trustworthy again. Once every market has been
+// prepared, the Monitor Service moves into a "ready" state.
+//
+func (o *Service) prepareMarket(asset string, event *exchange.Event) {
+  //
+  // If this is the first time we have connected, initialize the asset's candle stores with the
+  // first trade that we have seen. Otherwise, we are reconnecting after a gap and must instead
+  // reconcile the candle stores by closing out any candles that went stale while we were away.
+  //
+  // NOTE ~> Some feeds (e.g. Coinbase Pro) immediately provide the last trade that occurred prior to
+  //  subscribing. Others will make us wait for the next trade to occur.
+  //
+  if !candle.Instance().Initialized(asset) {
+    if err := candle.Instance().Init(asset, event.Time, event.Price); err != nil {
+      log.Fatalf("Failed to initialize the Candle Store Service. (Error: %s)", err)
     }
-  } else if o.state == ready {
-    if msg.Type == "match" {
-      //
-      // Extract the trade time and price from the message.
-      //
-      time := msg.Time.Time()
-      amt, err := decimal.NewFromString(msg.Price)
-      if err != nil {
-        log.Fatalf("Failed to parse price from message. (Message: %+v) (Error: %s)", msg, err)
-      }
+  } else if !o.appendTrade(asset, event) {
+    return
+  } else {
+    logger.Printf("Reconciled candle stores after reconnecting (Market: %s).", event.Symbol)
+  }
 
-      //
-      // Provide the trade to the candle store service.
-      //
-      closedCandles, err := candle.Instance().Append(time, amt)
-      if err != nil {
-        log.Fatalf("Failed to provide the trade to the Candle Store Service. (Error: %s)", err)
-      }
+  o.readyMarkets[asset] = true
 
-      //
-      // Process any candles that were closed out.
-      //
-      go o.processClosedCandles(closedCandles)
-    }
+  //
+  // Move the Trade Monitor Service into a "ready" state once every market has been prepared –
+  // indicating that it is now fully ready to begin monitoring and processing trades received from
+  // the websocket feed. Now that our candles are trustworthy again, new entries may resume.
+  //
+  if len(o.readyMarkets) < len(o.assets) {
+    return
   }
+
+  o.state = ready
+
+  broker.Instance().Resume()
 }
 
 //
-// processClosedCandles fires off any necessary signal handlers given the closed out candles
-// provided.
+// appendTrade provides the trade described by the provided event to the Candle Store Service and
+// processes any candles that were closed out as a result. Returns false if the trade could not be
+// appended (e.g. because it predates the candles currently being built).
 //
-func (o *Service) processClosedCandles(candles *candle.Candles) {
+func (o *Service) appendTrade(asset string, event *exchange.Event) bool {
+  //
+  // Let the Broker Service evaluate protective exits against the trade.
+  //
+  broker.Instance().Mark(asset, event.Price, event.Time)
+
+  //
+  // Provide the trade to the candle store service.
+  //
+  closedCandles, err := candle.Instance().Append(asset, event.Time, event.Price)
+  if err != nil {
+    logger.Printf("Failed to provide the trade to the Candle Store Service. (Error: %s)", err)
+
+    return false
+  }
+
+  //
+  // Process any candles that were closed out.
+  //
+  go o.processClosedCandles(asset, closedCandles)
+
+  return true
+}
+
+//
+// processClosedCandles fires off any necessary signal handlers given the closed out candles of the
+// specified asset provided.
+//
+func (o *Service) processClosedCandles(asset string, candles candle.Candles) {
   o.mu.Lock()
   defer o.mu.Unlock()
 
-  // NOTE ~> We must lock because we will be iterating slices that are members of the instance.
+  // NOTE ~> We must lock because we will be iterating slices that are members of the instance. This
+  //  also means that handlers must not subscribe or unsubscribe while they are being executed.
 
   //
   // Make sure candles were actually closed out.
   //
-  if candles.OneMin == nil && candles.FiveMin == nil && candles.FifteenMin == nil {
+  if len(candles) == 0 {
     return
   }
 
   //
   // Fire off necessary signal handlers.
   //
-  if candles.OneMin != nil {
-    for _, handler := range o.onOneMinCandleCloseHandlers {
-      /*go */handler(candles.OneMin)
-    }
-  }
-
-  if candles.FiveMin != nil {
-    for _, handler := range o.onFiveMinCandleCloseHandlers {
-      /*go */handler(candles.FiveMin)
-    }
-  }
-
-  if candles.FifteenMin != nil {
-    for _, handler := range o.onFifteenMinCandleCloseHandlers {
-      /*go */handler(candles.FifteenMin)
+  for _, interval := range candles.Intervals() {
+    for _, sub := range o.subscriptions {
+      if sub.asset == asset && sub.interval == interval {
+        /*go */sub.handler(candles[interval])
+      }
     }
   }
 
@@ -0,0 +1,96 @@
+package monitor
+
+import (
+  "fmt"
+  "github.com/lukehollenback/goose/trader/candle"
+  "time"
+)
+
+//
+// Subscription represents a handler that has subscribed to be executed whenever a candle of a
+// specific interval closes out for a specific market.
+//
+type Subscription struct {
+  service  *Service
+  asset    string
+  interval time.Duration
+  handler  func(*candle.Candle)
+}
+
+//
+// Asset returns the asset whose market the subscription is for.
+//
+func (o *Subscription) Asset() string {
+  return o.asset
+}
+
+//
+// Interval returns the interval of candles that the subscription is for.
+//
+func (o *Subscription) Interval() time.Duration {
+  return o.interval
+}
+
+//
+// Unsubscribe stops the subscription's handler from being executed when future candles close out.
+// Unsubscribing more than once has no effect.
+//
+func (o *Subscription) Unsubscribe() {
+  o.service.unsubscribe(o)
+}
+
+//
+// Subscribe registers a handler to be executed whenever a candle of the specified interval closes
+// out for the specified asset's market. Candles of any interval that is a whole number of minutes
+// may be subscribed to, but subscriptions to intervals that are not already being built must be
+// made before the Candle Service is initialized. The returned subscription can be used to
+// unsubscribe the handler.
+//
+func (o *Service) Subscribe(asset string, interval time.Duration, handler func(*candle.Candle)) (*Subscription, error) {
+  o.mu.Lock()
+  defer o.mu.Unlock()
+
+  //
+  // Make sure that the market is actually being monitored.
+  //
+  if !o.watching(asset) {
+    return nil, fmt.Errorf("the %s market is not being monitored", asset)
+  }
+
+  //
+  // Make sure that candles of the interval are (or will be) built.
+  //
+  if err := candle.Instance().AddInterval(interval); err != nil {
+    return nil, err
+  }
+
+  //
+  // Actually register the subscription.
+  //
+  sub := &Subscription{
+    service:  o,
+    asset:    asset,
+    interval: interval,
+    handler:  handler,
+  }
+
+  o.subscriptions = append(o.subscriptions, sub)
+
+  return sub, nil
+}
+
+//
+// unsubscribe removes the provided subscription so that its handler is no longer executed.
+//
+func (o *Service) unsubscribe(sub *Subscription) {
+  o.mu.Lock()
+  defer o.mu.Unlock()
+
+  for i, v := range o.subscriptions {
+    if v == sub {
+      o.subscriptions = append(o.subscriptions[:i], o.subscriptions[i+1:]...)
+
+      return
+    }
+  }
+}
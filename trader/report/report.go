@@ -0,0 +1,340 @@
+package report
+
+import (
+  "encoding/csv"
+  "fmt"
+  "github.com/lukehollenback/goose/constants"
+  "github.com/lukehollenback/goose/trader/broker"
+  "github.com/shopspring/decimal"
+  "io"
+  "math"
+  "strings"
+  "time"
+)
+
+//
+// Point is a sample of the value of a portfolio at a point in time.
+//
+type Point struct {
+  Time    time.Time
+  Equity  decimal.Decimal
+  Exposed bool // Whether or not any position was held.
+}
+
+//
+// Report describes how a portfolio performed over a period of time.
+//
+type Report struct {
+  Start         time.Time
+  End           time.Time
+  InitialEquity decimal.Decimal
+  FinalEquity   decimal.Decimal
+
+  TotalReturn         float64       // As a fraction (e.g. 0.02 for 2%).
+  CAGR                float64       // The compound annual growth rate, as a fraction.
+  Sharpe              float64       // Annualized, from daily returns, assuming a risk-free rate of zero.
+  Sortino             float64       // Annualized, from daily returns, assuming a target return of zero. Infinite if no day lost anything.
+  MaxDrawdown         float64       // The largest fall from a peak, as a fraction of the peak.
+  MaxDrawdownDuration time.Duration // The longest time spent below a previous peak.
+  Exposure            float64       // The fraction of the time that any position was held.
+
+  Trades       int
+  WinRate      float64
+  ProfitFactor float64         // Gross profit divided by gross loss. Infinite if nothing was lost.
+  AvgWin       decimal.Decimal // The average profit (in USD) of winning trades.
+  AvgLoss      decimal.Decimal // The average loss (in USD) of losing trades.
+  Fees         decimal.Decimal
+
+  Benchmark *Report // How simply buying and holding over the same period performed, if known.
+}
+
+//
+// Compute builds a report from the provided equity curve, the trades that were completed, and the
+// fees that were paid. The curve must be in chronological order and have at least one point.
+//
+func Compute(curve []Point, trades []broker.Trade, fees decimal.Decimal) *Report {
+  o := computeCurve(curve)
+  o.Fees = fees
+  o.Trades = len(trades)
+  o.AvgWin = decimal.Zero
+  o.AvgLoss = decimal.Zero
+
+  //
+  // Break the trades down into winners and losers.
+  //
+  wins := 0
+  grossWin := decimal.Zero
+  grossLoss := decimal.Zero
+
+  for _, trade := range trades {
+    if pnl := trade.PnL(); pnl.GreaterThan(decimal.Zero) {
+      wins++
+      grossWin = grossWin.Add(pnl)
+    } else {
+      grossLoss = grossLoss.Add(pnl.Abs())
+    }
+  }
+
+  if wins > 0 {
+    o.AvgWin = grossWin.Div(decimal.NewFromInt(int64(wins)))
+  }
+
+  if losses := len(trades) - wins; losses > 0 {
+    o.AvgLoss = grossLoss.Div(decimal.NewFromInt(int64(losses)))
+  }
+
+  if len(trades) > 0 {
+    o.WinRate = float64(wins) / float64(len(trades))
+  }
+
+  if grossLoss.GreaterThan(decimal.Zero) {
+    o.ProfitFactor = toFloat(grossWin.Div(grossLoss))
+  } else if grossWin.GreaterThan(decimal.Zero) {
+    o.ProfitFactor = math.Inf(1)
+  }
+
+  return o
+}
+
+//
+// computeCurve builds a report from the provided equity curve alone.
+//
+func computeCurve(curve []Point) *Report {
+  first := curve[0]
+  last := curve[len(curve)-1]
+
+  o := &Report{
+    Start:         first.Time,
+    End:           last.Time,
+    InitialEquity: first.Equity,
+    FinalEquity:   last.Equity,
+  }
+
+  if !first.Equity.GreaterThan(decimal.Zero) {
+    return o
+  }
+
+  growth := toFloat(last.Equity.Div(first.Equity))
+  o.TotalReturn = growth - 1
+
+  if years := last.Time.Sub(first.Time).Hours() / 24 / 365.25; years > 0 && growth > 0 {
+    o.CAGR = math.Pow(growth, 1/years) - 1
+  }
+
+  o.Sharpe, o.Sortino = ratios(dailyReturns(curve))
+  o.MaxDrawdown, o.MaxDrawdownDuration = drawdown(curve)
+
+  //
+  // Work out how much of the time a position was held, weighting each point by how long it lasted.
+  //
+  if total := last.Time.Sub(first.Time); total > 0 {
+    var exposed time.Duration
+
+    for i := 1; i < len(curve); i++ {
+      if curve[i-1].Exposed {
+        exposed += curve[i].Time.Sub(curve[i-1].Time)
+      }
+    }
+
+    o.Exposure = float64(exposed) / float64(total)
+  }
+
+  return o
+}
+
+//
+// dailyReturns samples the provided equity curve at the end of each (UTC) day and returns the
+// return of each day relative to the one before it.
+//
+func dailyReturns(curve []Point) []float64 {
+  //
+  // NOTE ~> The initial equity is kept as the baseline that the first day's return is measured
+  //  against.
+  //
+  closes := []decimal.Decimal{curve[0].Equity}
+
+  var day time.Time
+
+  for _, point := range curve {
+    if d := point.Time.UTC().Truncate(constants.OneDay); len(closes) == 1 || d.After(day) {
+      day = d
+      closes = append(closes, point.Equity)
+    } else {
+      closes[len(closes)-1] = point.Equity
+    }
+  }
+
+  returns := make([]float64, 0, len(closes)-1)
+
+  for i := 1; i < len(closes); i++ {
+    if closes[i-1].GreaterThan(decimal.Zero) {
+      returns = append(returns, toFloat(closes[i].Div(closes[i-1]))-1)
+    }
+  }
+
+  return returns
+}
+
+//
+// ratios determines the annualized Sharpe and Sortino ratios of the provided daily returns.
+//
+// NOTE ~> Markets are assumed to trade every day of the year, as cryptocurrency markets do.
+//
+func ratios(returns []float64) (float64, float64) {
+  if len(returns) < 2 {
+    return 0, 0
+  }
+
+  mean := 0.0
+
+  for _, r := range returns {
+    mean += r
+  }
+
+  mean /= float64(len(returns))
+
+  variance := 0.0
+  downside := 0.0
+
+  for _, r := range returns {
+    variance += (r - mean) * (r - mean)
+
+    if r < 0 {
+      downside += r * r
+    }
+  }
+
+  stdDev := math.Sqrt(variance / float64(len(returns)-1))
+  downDev := math.Sqrt(downside / float64(len(returns)))
+  annualize := math.Sqrt(365)
+
+  sharpe := 0.0
+  sortino := 0.0
+
+  if stdDev > 0 {
+    sharpe = mean / stdDev * annualize
+  }
+
+  if downDev > 0 {
+    sortino = mean / downDev * annualize
+  } else if mean > 0 {
+    sortino = math.Inf(1)
+  }
+
+  return sharpe, sortino
+}
+
+//
+// drawdown determines the largest fall (as a fraction) from a peak of the provided equity curve, as
+// well as the longest time that it spent below a previous peak.
+//
+func drawdown(curve []Point) (float64, time.Duration) {
+  peak := curve[0].Equity
+  peakTime := curve[0].Time
+  maxDrawdown := 0.0
+
+  var maxDuration time.Duration
+
+  for _, point := range curve {
+    if !point.Equity.LessThan(peak) {
+      peak = point.Equity
+      peakTime = point.Time
+
+      continue
+    }
+
+    if dd := toFloat(peak.Sub(point.Equity).Div(peak)); dd > maxDrawdown {
+      maxDrawdown = dd
+    }
+
+    if duration := point.Time.Sub(peakTime); duration > maxDuration {
+      maxDuration = duration
+    }
+  }
+
+  return maxDrawdown, maxDuration
+}
+
+//
+// String renders the report as a human-readable table.
+//
+func (o *Report) String() string {
+  var sb strings.Builder
+
+  sb.WriteString(fmt.Sprintf("Period:                 %s to %s\n", o.Start.Format(time.RFC3339), o.End.Format(time.RFC3339)))
+  sb.WriteString(fmt.Sprintf("Equity:                 %s USD to %s USD\n", o.InitialEquity.StringFixed(2), o.FinalEquity.StringFixed(2)))
+
+  for _, row := range o.rows() {
+    sb.WriteString(fmt.Sprintf("%-24s%s", row[0]+":", row[1]))
+
+    if o.Benchmark != nil && row[2] != "" {
+      sb.WriteString(fmt.Sprintf(" (Buy & Hold: %s)", row[2]))
+    }
+
+    sb.WriteString("\n")
+  }
+
+  return sb.String()
+}
+
+//
+// WriteCSV writes the report out to the provided writer as CSV, with a row for each statistic and
+// a column each for the portfolio and the benchmark.
+//
+func (o *Report) WriteCSV(w io.Writer) error {
+  rows := [][]string{{"Statistic", "Value", "BuyAndHold"}}
+
+  for _, row := range o.rows() {
+    rows = append(rows, row)
+  }
+
+  return csv.NewWriter(w).WriteAll(rows)
+}
+
+//
+// rows returns the name of each statistic along with its value for the portfolio and (if it
+// applies) for the benchmark.
+//
+func (o *Report) rows() [][]string {
+  bench := o.Benchmark
+  if bench == nil {
+    bench = &Report{}
+  }
+
+  return [][]string{
+    {"Total Return", percent(o.TotalReturn), percent(bench.TotalReturn)},
+    {"CAGR", percent(o.CAGR), percent(bench.CAGR)},
+    {"Sharpe Ratio", fmt.Sprintf("%.2f", o.Sharpe), fmt.Sprintf("%.2f", bench.Sharpe)},
+    {"Sortino Ratio", fmt.Sprintf("%.2f", o.Sortino), fmt.Sprintf("%.2f", bench.Sortino)},
+    {"Max Drawdown", percent(o.MaxDrawdown), percent(bench.MaxDrawdown)},
+    {"Max Drawdown Duration", o.MaxDrawdownDuration.String(), bench.MaxDrawdownDuration.String()},
+    {"Exposure Time", percent(o.Exposure), percent(bench.Exposure)},
+    {"Trades", fmt.Sprintf("%d", o.Trades), ""},
+    {"Win Rate", percent(o.WinRate), ""},
+    {"Profit Factor", fmt.Sprintf("%.2f", o.ProfitFactor), ""},
+    {"Average Win", fmt.Sprintf("%s USD", o.AvgWin.StringFixed(2)), ""},
+    {"Average Loss", fmt.Sprintf("%s USD", o.AvgLoss.StringFixed(2)), ""},
+    {"Total Fees", fmt.Sprintf("%s USD", o.Fees.StringFixed(2)), ""},
+  }
+}
+
+//
+// percent renders the provided fraction as a percentage. Huge percentages (e.g. the CAGR of a very
+// short backtest) are rendered in scientific notation.
+//
+func percent(fraction float64) string {
+  if math.Abs(fraction) >= 1e4 {
+    return fmt.Sprintf("%.2e%%", fraction*100)
+  }
+
+  return fmt.Sprintf("%.2f%%", fraction*100)
+}
+
+//
+// toFloat converts the provided decimal into a float for statistics that do not need to be exact.
+//
+func toFloat(d decimal.Decimal) float64 {
+  f, _ := d.Float64()
+
+  return f
+}
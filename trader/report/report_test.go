@@ -0,0 +1,79 @@
+package report
+
+import (
+  "github.com/lukehollenback/goose/trader/broker"
+  "github.com/shopspring/decimal"
+  "math"
+  "testing"
+  "time"
+)
+
+func TestComputeCurveStatistics(t *testing.T) {
+  start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+  point := func(hours int, equity int64, exposed bool) Point {
+    return Point{Time: start.Add(time.Duration(hours) * time.Hour), Equity: decimal.NewFromInt(equity), Exposed: exposed}
+  }
+
+  //
+  // Rise to 1,200, fall by a quarter to 900, and then recover to 1,320 – spending half of the time
+  // holding a position.
+  //
+  curve := []Point{
+    point(0, 1000, true),
+    point(24, 1200, false),
+    point(48, 900, true),
+    point(72, 1100, false),
+    point(96, 1320, false),
+  }
+
+  report := Compute(curve, nil, decimal.Zero)
+
+  if math.Abs(report.TotalReturn-0.32) > 1e-9 {
+    t.Errorf("Expected a total return of 32%% but got %f.", report.TotalReturn)
+  }
+
+  if math.Abs(report.MaxDrawdown-0.25) > 1e-9 {
+    t.Errorf("Expected a max drawdown of 25%% but got %f.", report.MaxDrawdown)
+  }
+
+  if report.MaxDrawdownDuration != 48*time.Hour {
+    t.Errorf("Expected the max drawdown to last 48h but it lasted %s.", report.MaxDrawdownDuration)
+  }
+
+  if math.Abs(report.Exposure-0.5) > 1e-9 {
+    t.Errorf("Expected an exposure time of 50%% but got %f.", report.Exposure)
+  }
+
+  if report.Sharpe <= 0 || report.Sortino <= 0 {
+    t.Errorf("Expected positive Sharpe and Sortino ratios but got %f and %f.", report.Sharpe, report.Sortino)
+  }
+}
+
+func TestComputeTradeStatistics(t *testing.T) {
+  start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+  curve := []Point{{Time: start, Equity: decimal.NewFromInt(1000)}, {Time: start.Add(time.Hour), Equity: decimal.NewFromInt(1010)}}
+
+  trades := []broker.Trade{
+    {Cost: decimal.NewFromInt(100), Proceeds: decimal.NewFromInt(130)},
+    {Cost: decimal.NewFromInt(100), Proceeds: decimal.NewFromInt(110)},
+    {Cost: decimal.NewFromInt(100), Proceeds: decimal.NewFromInt(80)},
+  }
+
+  report := Compute(curve, trades, decimal.NewFromInt(3))
+
+  if report.Trades != 3 || math.Abs(report.WinRate-2.0/3.0) > 1e-9 {
+    t.Errorf("Expected 3 trades with a 2/3 win rate but got %d trades with a %f win rate.", report.Trades, report.WinRate)
+  }
+
+  if math.Abs(report.ProfitFactor-2) > 1e-9 {
+    t.Errorf("Expected a profit factor of 2 but got %f.", report.ProfitFactor)
+  }
+
+  if !report.AvgWin.Equal(decimal.NewFromInt(20)) || !report.AvgLoss.Equal(decimal.NewFromInt(20)) {
+    t.Errorf("Expected an average win and loss of 20 USD but got %s and %s.", report.AvgWin, report.AvgLoss)
+  }
+
+  if !report.Fees.Equal(decimal.NewFromInt(3)) {
+    t.Errorf("Expected 3 USD of fees but got %s.", report.Fees)
+  }
+}
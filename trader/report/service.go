@@ -0,0 +1,239 @@
+package report
+
+import (
+  "flag"
+  "fmt"
+  "github.com/lukehollenback/goose/constants"
+  "github.com/lukehollenback/goose/trader/broker"
+  "github.com/lukehollenback/goose/trader/candle"
+  "github.com/shopspring/decimal"
+  "log"
+  "os"
+  "strings"
+  "sync"
+)
+
+const (
+  Name = "≪report-service≫"
+)
+
+var (
+  o      *Service
+  once   sync.Once
+  logger *log.Logger
+
+  cfgReportFile *string
+)
+
+func init() {
+  //
+  // Initialize the logger.
+  //
+  logger = log.New(log.Writer(), fmt.Sprintf(constants.LogPrefixFmt, Name), log.Ldate|log.Ltime|log.Lmsgprefix)
+
+  //
+  // Register and parse configuration flags.
+  //
+  cfgReportFile = flag.String(
+    "report-file",
+    "",
+    "The file that the performance report of a backtest should be exported to as CSV. The report is always "+
+        "logged. Not exported if empty.",
+  )
+}
+
+//
+// Portfolio describes what the Report Service needs from the Broker Service.
+//
+type Portfolio interface {
+  Equity() decimal.Decimal
+  Exposure(asset string) decimal.Decimal
+  Ledger() *broker.Ledger
+}
+
+//
+// Service represents a service instance. It samples the value of the portfolio as candles close so
+// that a performance report can be built once a backtest has completed. It also tracks how simply
+// buying and holding each asset (split evenly) over the same candles would have performed.
+//
+type Service struct {
+  mu        *sync.Mutex
+  portfolio Portfolio
+  assets    []string
+  file      string
+
+  curve      []Point
+  benchmark  []Point
+  firstOpens map[string]decimal.Decimal // The price that the benchmark bought each asset at.
+  lastCloses map[string]decimal.Decimal // The most recent price of each asset.
+  initEquity decimal.Decimal            // The value of the portfolio before anything was traded.
+}
+
+//
+// Instance returns a singleton instance of the service.
+//
+func Instance() *Service {
+  once.Do(func() {
+    o = newService(broker.Instance(), *cfgReportFile)
+  })
+
+  return o
+}
+
+//
+// newService instantiates a new service that reports on the provided portfolio.
+//
+func newService(portfolio Portfolio, file string) *Service {
+  return &Service{
+    mu:         &sync.Mutex{},
+    portfolio:  portfolio,
+    file:       file,
+    firstOpens: make(map[string]decimal.Decimal),
+    lastCloses: make(map[string]decimal.Decimal),
+  }
+}
+
+//
+// SetAssets tells the Report Service which assets are being traded. These should be the same assets
+// that are being traded by the Broker Service.
+//
+func (o *Service) SetAssets(assets []string) {
+  o.mu.Lock()
+  defer o.mu.Unlock()
+
+  o.assets = append([]string{}, assets...)
+}
+
+//
+// Observe provides the Report Service with a newly-closed candle of the specified asset's market so
+// that it can sample the value of the portfolio (and of the benchmark).
+//
+func (o *Service) Observe(asset string, newCandle *candle.Candle) {
+  o.mu.Lock()
+  defer o.mu.Unlock()
+
+  //
+  // The portfolio's value before the first candle closes is what both it and the benchmark start
+  // out with.
+  //
+  if len(o.curve) == 0 {
+    o.initEquity = o.portfolio.Equity()
+  }
+
+  if _, ok := o.firstOpens[asset]; !ok {
+    o.firstOpens[asset] = newCandle.OpenAmt()
+  }
+
+  o.lastCloses[asset] = newCandle.CloseAmt()
+
+  //
+  // Sample the portfolio.
+  //
+  exposed := false
+
+  for _, v := range o.assets {
+    if o.portfolio.Exposure(v).GreaterThan(decimal.Zero) {
+      exposed = true
+    }
+  }
+
+  o.curve = sample(o.curve, Point{Time: newCandle.End(), Equity: o.portfolio.Equity(), Exposed: exposed})
+
+  //
+  // Sample the benchmark. Assets that have not been seen yet are still held as USD.
+  //
+  share := decimal.Zero
+  if len(o.assets) > 0 {
+    share = o.initEquity.Div(decimal.NewFromInt(int64(len(o.assets))))
+  }
+
+  value := decimal.Zero
+
+  for _, v := range o.assets {
+    open, ok := o.firstOpens[v]
+
+    if !ok || !open.GreaterThan(decimal.Zero) {
+      value = value.Add(share)
+    } else {
+      value = value.Add(share.Div(open).Mul(o.lastCloses[v]))
+    }
+  }
+
+  o.benchmark = sample(o.benchmark, Point{Time: newCandle.End(), Equity: value, Exposed: true})
+}
+
+//
+// Report builds a performance report from everything that has been observed so far. Returns nil if
+// nothing has been observed.
+//
+func (o *Service) Report() *Report {
+  o.mu.Lock()
+  defer o.mu.Unlock()
+
+  if len(o.curve) == 0 {
+    return nil
+  }
+
+  //
+  // Both curves start out at the initial value of the portfolio.
+  //
+  start := Point{Time: o.curve[0].Time, Equity: o.initEquity}
+  ledger := o.portfolio.Ledger()
+
+  report := Compute(append([]Point{start}, o.curve...), ledger.Trades(""), ledger.Fees())
+  report.Benchmark = computeCurve(append([]Point{start}, o.benchmark...))
+
+  return report
+}
+
+//
+// Complete builds a performance report from everything that has been observed, logs it, and
+// exports it (if configured to). It should be called once a backtest has completed.
+//
+func (o *Service) Complete() {
+  report := o.Report()
+  if report == nil {
+    logger.Printf("Nothing to report on. No candles were observed.")
+
+    return
+  }
+
+  logger.Printf("Backtest performance report:\n%s", strings.TrimRight(report.String(), "\n"))
+
+  if o.file == "" {
+    return
+  }
+
+  f, err := os.Create(o.file)
+  if err != nil {
+    logger.Printf("Failed to create report file. (Error: %s)", err)
+
+    return
+  }
+
+  defer func() {
+    _ = f.Close()
+  }()
+
+  if err := report.WriteCSV(f); err != nil {
+    logger.Printf("Failed to export report. (Error: %s)", err)
+
+    return
+  }
+
+  logger.Printf("Exported report to %s.", o.file)
+}
+
+//
+// sample adds the provided point to the provided curve, replacing the last point instead if it was
+// sampled at the same time (e.g. because several markets closed candles at once).
+//
+func sample(curve []Point, point Point) []Point {
+  if n := len(curve); n > 0 && curve[n-1].Time.Equal(point.Time) {
+    curve[n-1] = point
+
+    return curve
+  }
+
+  return append(curve, point)
+}
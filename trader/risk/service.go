@@ -0,0 +1,316 @@
+package risk
+
+import (
+  "flag"
+  "fmt"
+  "github.com/logrusorgru/aurora"
+  "github.com/lukehollenback/goose/constants"
+  "github.com/lukehollenback/goose/trader/broker"
+  "github.com/lukehollenback/goose/trader/writer"
+  "github.com/shopspring/decimal"
+  "log"
+  "sync"
+  "time"
+)
+
+const (
+  Name = "≪risk-manager≫"
+)
+
+var (
+  o      *Service
+  once   sync.Once
+  logger *log.Logger
+
+  cfgMaxDailyLoss     *string
+  cfgMaxDrawdown      *string
+  cfgMaxTradesPerHour *int
+  cfgMaxExposure      *string
+  cfgFlatten          *bool
+)
+
+func init() {
+  //
+  // Initialize the logger.
+  //
+  logger = log.New(log.Writer(), fmt.Sprintf(constants.LogPrefixFmt, Name), log.Ldate|log.Ltime|log.Lmsgprefix)
+
+  //
+  // Register and parse configuration flags.
+  //
+  cfgMaxDailyLoss = flag.String(
+    "risk-max-daily-loss",
+    "",
+    "How much equity may be lost within a single (UTC) day before new entries are halted until the next day. "+
+        "Either a percentage of the day's starting equity (e.g. 5%) or an absolute amount of USD (e.g. 200). "+
+        "Disabled if empty.",
+  )
+
+  cfgMaxDrawdown = flag.String(
+    "risk-max-drawdown",
+    "",
+    "How far equity may fall from its peak before new entries are halted for good. Either a percentage of the "+
+        "peak (e.g. 20%) or an absolute amount of USD (e.g. 1000). Disabled if empty.",
+  )
+
+  cfgMaxTradesPerHour = flag.Int(
+    "risk-max-trades-per-hour",
+    0,
+    "How many trades may be executed within an hour before new entries are halted for an hour. Disabled if 0.",
+  )
+
+  cfgMaxExposure = flag.String(
+    "risk-max-exposure",
+    "",
+    "The most that may be held of any one asset. Either a percentage of equity (e.g. 25%) or an absolute "+
+        "amount of USD (e.g. 500). Disabled if empty.",
+  )
+
+  cfgFlatten = flag.Bool(
+    "risk-flatten",
+    false,
+    "Whether or not all positions should be exited when a risk limit halts new entries.",
+  )
+}
+
+//
+// Broker describes what the risk manager needs from the Broker Service.
+//
+type Broker interface {
+  SignalCapped(asset string, source string, signal broker.Signal, price decimal.Decimal, timestamp time.Time, maxSpend *decimal.Decimal)
+  Equity() decimal.Decimal
+  Exposure(asset string) decimal.Decimal
+  Clock() time.Time
+  RecentTrades(window time.Duration) int
+  Flatten(reason string)
+}
+
+//
+// Service represents a risk manager instance. It sits between strategies and the Broker Service,
+// enforcing portfolio-level limits on the signals that strategies emit. Exits are always allowed
+// through, but new entries are halted whenever a limit is tripped.
+//
+type Service struct {
+  mu     *sync.Mutex
+  broker Broker
+
+  maxDailyLoss     *broker.Threshold
+  maxDrawdown      *broker.Threshold
+  maxTradesPerHour int
+  maxExposure      *broker.Threshold
+  flatten          bool
+
+  halted     bool
+  haltReason string
+  haltUntil  time.Time // When the current halt ends. Zero if it never does.
+
+  day            time.Time       // The start of the (UTC) day that the daily loss limit is tracking.
+  dayStartEquity decimal.Decimal // Equity at the start of the day.
+  peakEquity     decimal.Decimal // The highest equity that has been seen.
+}
+
+//
+// Instance returns a singleton instance of the risk manager.
+//
+func Instance() *Service {
+  once.Do(func() {
+    var err error
+
+    o = &Service{
+      mu:               &sync.Mutex{},
+      broker:           broker.Instance(),
+      maxTradesPerHour: *cfgMaxTradesPerHour,
+      flatten:          *cfgFlatten,
+      peakEquity:       decimal.Zero,
+    }
+
+    if o.maxDailyLoss, err = broker.ParseThreshold(*cfgMaxDailyLoss); err != nil {
+      logger.Fatalf("Failed to instantiate. Maximum daily loss could not be parsed. (Error: %s)", err)
+    }
+
+    if o.maxDrawdown, err = broker.ParseThreshold(*cfgMaxDrawdown); err != nil {
+      logger.Fatalf("Failed to instantiate. Maximum drawdown could not be parsed. (Error: %s)", err)
+    }
+
+    if o.maxExposure, err = broker.ParseThreshold(*cfgMaxExposure); err != nil {
+      logger.Fatalf("Failed to instantiate. Maximum exposure could not be parsed. (Error: %s)", err)
+    }
+
+    logger.Printf(
+      "Initialized. (Max Daily Loss: %s, Max Drawdown: %s, Max Trades/Hour: %d, Max Exposure: %s, Flatten: %t)",
+      o.maxDailyLoss, o.maxDrawdown, o.maxTradesPerHour, o.maxExposure, o.flatten,
+    )
+  })
+
+  return o
+}
+
+//
+// Signal evaluates the risk limits and then passes the provided signal (from the provided source,
+// e.g. the name of a strategy) on to the Broker Service – unless it is an entry and entries are
+// halted or the asset is already at its maximum exposure.
+//
+func (o *Service) Signal(asset string, source string, signal broker.Signal, price decimal.Decimal, timestamp time.Time) {
+  o.mu.Lock()
+  defer o.mu.Unlock()
+
+  o.evaluate(timestamp)
+
+  //
+  // Exits are always allowed through, as they can only ever reduce risk.
+  //
+  if signal != broker.UptrendDetected {
+    o.broker.SignalCapped(asset, source, signal, price, timestamp, nil)
+
+    return
+  }
+
+  //
+  // Block entries while halted.
+  //
+  if o.halted {
+    logger.Printf("Blocking %s entry signal (at %s) because entries are halted. (Reason: %s)", asset, price, o.haltReason)
+
+    return
+  }
+
+  //
+  // Limit the size of the entry to whatever room is left under the asset's maximum exposure.
+  //
+  var maxSpend *decimal.Decimal
+
+  if o.maxExposure != nil {
+    room := o.maxExposure.Of(o.broker.Equity()).Sub(o.broker.Exposure(asset))
+
+    if !room.GreaterThan(decimal.Zero) {
+      logger.Printf("Blocking %s entry signal (at %s) because the asset is at its maximum exposure.", asset, price)
+
+      return
+    }
+
+    maxSpend = &room
+  }
+
+  o.broker.SignalCapped(asset, source, signal, price, timestamp, maxSpend)
+}
+
+//
+// Evaluate checks every risk limit against the current state of the Broker Service, halting new
+// entries if any of them have been tripped (or resuming them if a halt has run its course). It
+// should be called regularly (e.g. whenever candles close) so that limits trip even when no signals
+// are being emitted.
+//
+func (o *Service) Evaluate() {
+  o.mu.Lock()
+  defer o.mu.Unlock()
+
+  o.evaluate(time.Time{})
+}
+
+//
+// evaluate checks every risk limit as of the later of the provided timestamp and the Broker
+// Service's clock. The caller must hold the service's lock.
+//
+func (o *Service) evaluate(timestamp time.Time) {
+  now := o.broker.Clock()
+  if timestamp.After(now) {
+    now = timestamp
+  }
+
+  if now.IsZero() {
+    return
+  }
+
+  equity := o.broker.Equity()
+
+  //
+  // Resume entries if the current halt has run its course.
+  //
+  if o.halted && !o.haltUntil.IsZero() && !now.Before(o.haltUntil) {
+    o.halted = false
+
+    logger.Printf("Resumed entries. The halt has run its course. (Reason: %s)", o.haltReason)
+  }
+
+  //
+  // Roll over to a new day if necessary.
+  //
+  if day := now.UTC().Truncate(constants.OneDay); !day.Equal(o.day) {
+    o.day = day
+    o.dayStartEquity = equity
+  }
+
+  //
+  // Track the peak equity.
+  //
+  if equity.GreaterThan(o.peakEquity) {
+    o.peakEquity = equity
+  }
+
+  //
+  // Check each limit. The most severe halts are checked first so that they are not masked by
+  // shorter ones.
+  //
+  if o.maxDrawdown != nil {
+    if drawdown := o.peakEquity.Sub(equity); drawdown.GreaterThan(o.maxDrawdown.Of(o.peakEquity)) {
+      o.halt(now, equity, time.Time{}, fmt.Sprintf("drawdown of %s USD from peak equity exceeds %s", drawdown, o.maxDrawdown))
+
+      return
+    }
+  }
+
+  if o.maxDailyLoss != nil {
+    if loss := o.dayStartEquity.Sub(equity); loss.GreaterThan(o.maxDailyLoss.Of(o.dayStartEquity)) {
+      o.halt(now, equity, o.day.Add(constants.OneDay), fmt.Sprintf("daily loss of %s USD exceeds %s", loss, o.maxDailyLoss))
+
+      return
+    }
+  }
+
+  //
+  // NOTE ~> The trade rate is only checked while not halted, as it cannot grow through entries while
+  //  halted anyways and would otherwise keep pushing the end of the halt back.
+  //
+  if o.maxTradesPerHour > 0 && !o.halted {
+    if trades := o.broker.RecentTrades(time.Hour); trades >= o.maxTradesPerHour {
+      o.halt(now, equity, now.Add(time.Hour), fmt.Sprintf("%d trades within the last hour", trades))
+    }
+  }
+}
+
+//
+// halt stops new entries until the provided time (or indefinitely if it is zero), logging the halt
+// and writing it out through the Writer Service. If configured to, all positions are also exited.
+// Tripping a limit while already halted only ever extends the halt.
+//
+func (o *Service) halt(now time.Time, equity decimal.Decimal, until time.Time, reason string) {
+  if o.halted && (o.haltUntil.IsZero() || (!until.IsZero() && !until.After(o.haltUntil))) {
+    return
+  }
+
+  o.halted = true
+  o.haltReason = reason
+  o.haltUntil = until
+
+  if until.IsZero() {
+    logger.Printf("%s new entries indefinitely. (Reason: %s)", aurora.Bold(aurora.Red("HALTED")), reason)
+  } else {
+    logger.Printf("%s new entries until %s. (Reason: %s)", aurora.Bold(aurora.Red("HALTED")), until, reason)
+  }
+
+  _ = writer.Instance().Write(now, writer.RiskHalt, equity)
+
+  if o.flatten {
+    o.broker.Flatten(reason)
+  }
+}
+
+//
+// Halted returns whether or not new entries are currently halted, and why.
+//
+func (o *Service) Halted() (bool, string) {
+  o.mu.Lock()
+  defer o.mu.Unlock()
+
+  return o.halted, o.haltReason
+}
@@ -0,0 +1,112 @@
+package risk
+
+import (
+  "github.com/lukehollenback/goose/trader/broker"
+  "github.com/shopspring/decimal"
+  "sync"
+  "testing"
+  "time"
+)
+
+//
+// stubBroker is a Broker whose state is set directly by tests, and which records the signals that
+// make it through the risk manager.
+//
+type stubBroker struct {
+  equity    decimal.Decimal
+  exposure  decimal.Decimal
+  clock     time.Time
+  trades    int
+  flattened int
+  signals   []broker.Signal
+  maxSpends []*decimal.Decimal
+}
+
+func (o *stubBroker) SignalCapped(asset string, source string, signal broker.Signal, price decimal.Decimal, timestamp time.Time, maxSpend *decimal.Decimal) {
+  o.signals = append(o.signals, signal)
+  o.maxSpends = append(o.maxSpends, maxSpend)
+}
+
+func (o *stubBroker) Equity() decimal.Decimal               { return o.equity }
+func (o *stubBroker) Exposure(asset string) decimal.Decimal { return o.exposure }
+func (o *stubBroker) Clock() time.Time                      { return o.clock }
+func (o *stubBroker) RecentTrades(window time.Duration) int { return o.trades }
+func (o *stubBroker) Flatten(reason string)                 { o.flattened++ }
+
+func newTestService(stub *stubBroker) *Service {
+  return &Service{mu: &sync.Mutex{}, broker: stub, peakEquity: decimal.Zero}
+}
+
+func TestDrawdownHaltsEntriesButNotExits(t *testing.T) {
+  stub := &stubBroker{equity: decimal.NewFromInt(1000), exposure: decimal.Zero, clock: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+  o := newTestService(stub)
+  o.maxDrawdown, _ = broker.ParseThreshold("10%")
+  o.flatten = true
+
+  o.Signal("BTC", "test", broker.UptrendDetected, decimal.NewFromInt(100), stub.clock)
+
+  stub.equity = decimal.NewFromInt(850)
+  stub.clock = stub.clock.Add(time.Minute)
+
+  o.Signal("BTC", "test", broker.UptrendDetected, decimal.NewFromInt(85), stub.clock)
+  o.Signal("BTC", "test", broker.DowntrendDetected, decimal.NewFromInt(85), stub.clock)
+
+  if halted, _ := o.Halted(); !halted {
+    t.Fatalf("Expected a 15%% drawdown to trip a 10%% drawdown limit.")
+  }
+
+  if len(stub.signals) != 2 || stub.signals[0] != broker.UptrendDetected || stub.signals[1] != broker.DowntrendDetected {
+    t.Errorf("Expected only the first entry and the exit to reach the broker but got %v.", stub.signals)
+  }
+
+  if stub.flattened != 1 {
+    t.Errorf("Expected positions to be flattened exactly once but they were flattened %d times.", stub.flattened)
+  }
+}
+
+func TestTradeRateHaltRunsItsCourse(t *testing.T) {
+  start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+  stub := &stubBroker{equity: decimal.NewFromInt(1000), exposure: decimal.Zero, clock: start, trades: 3}
+  o := newTestService(stub)
+  o.maxTradesPerHour = 3
+
+  o.Evaluate()
+
+  if halted, _ := o.Halted(); !halted {
+    t.Fatalf("Expected reaching the maximum trades per hour to halt entries.")
+  }
+
+  stub.clock = start.Add(30 * time.Minute)
+  o.Evaluate()
+
+  if !o.haltUntil.Equal(start.Add(time.Hour)) {
+    t.Errorf("Expected the halt to still end at %s but it instead ends at %s.", start.Add(time.Hour), o.haltUntil)
+  }
+
+  stub.clock = start.Add(time.Hour)
+  stub.trades = 0
+  o.Evaluate()
+
+  if halted, _ := o.Halted(); halted {
+    t.Errorf("Expected entries to resume once the halt has run its course.")
+  }
+}
+
+func TestExposureCapsEntries(t *testing.T) {
+  stub := &stubBroker{equity: decimal.NewFromInt(1000), exposure: decimal.NewFromInt(150), clock: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+  o := newTestService(stub)
+  o.maxExposure, _ = broker.ParseThreshold("25%")
+
+  o.Signal("BTC", "test", broker.UptrendDetected, decimal.NewFromInt(100), stub.clock)
+
+  if len(stub.maxSpends) != 1 || stub.maxSpends[0] == nil || !stub.maxSpends[0].Equal(decimal.NewFromInt(100)) {
+    t.Fatalf("Expected the entry to be capped at the 100 USD of room left under the maximum exposure.")
+  }
+
+  stub.exposure = decimal.NewFromInt(250)
+  o.Signal("BTC", "test", broker.UptrendDetected, decimal.NewFromInt(100), stub.clock)
+
+  if len(stub.signals) != 1 {
+    t.Errorf("Expected an entry at the maximum exposure to be blocked.")
+  }
+}
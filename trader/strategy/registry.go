@@ -0,0 +1,77 @@
+package strategy
+
+import (
+  "fmt"
+  "sort"
+  "sync"
+)
+
+//
+// Factory creates a new, independent instance of a strategy.
+//
+type Factory func() (Strategy, error)
+
+var (
+  registryMu = &sync.Mutex{}
+  registry   = make(map[string]Factory)
+)
+
+//
+// Register makes a strategy available under the provided name. Strategies should call this from
+// their package initialization functions. Registering the same name twice panics, as it is always a
+// programming error.
+//
+func Register(name string, factory Factory) {
+  registryMu.Lock()
+  defer registryMu.Unlock()
+
+  if _, ok := registry[name]; ok {
+    panic(fmt.Sprintf("strategy %s has already been registered", name))
+  }
+
+  registry[name] = factory
+}
+
+//
+// New creates a new instance of the strategy registered under the provided name.
+//
+func New(name string) (Strategy, error) {
+  registryMu.Lock()
+  factory, ok := registry[name]
+  registryMu.Unlock()
+
+  if !ok {
+    return nil, fmt.Errorf("unknown strategy %s (registered strategies are %v)", name, Names())
+  }
+
+  return factory()
+}
+
+//
+// Names returns the names of all registered strategies in alphabetical order.
+//
+func Names() []string {
+  registryMu.Lock()
+  defer registryMu.Unlock()
+
+  names := make([]string, 0, len(registry))
+
+  for name := range registry {
+    names = append(names, name)
+  }
+
+  sort.Strings(names)
+
+  return names
+}
+
+//
+// unregister makes the strategy registered under the provided name unavailable again. It exists so
+// that tests can clean up after registering strategies of their own.
+//
+func unregister(name string) {
+  registryMu.Lock()
+  defer registryMu.Unlock()
+
+  delete(registry, name)
+}
@@ -0,0 +1,60 @@
+package strategy
+
+import (
+  "github.com/lukehollenback/goose/trader/broker"
+  "github.com/lukehollenback/goose/trader/candle"
+  "testing"
+  "time"
+)
+
+//
+// countingStrategy is a trivial strategy that counts the candles it has been provided with.
+//
+type countingStrategy struct {
+  count int
+}
+
+func (o *countingStrategy) Name() string {
+  return "counting"
+}
+
+func (o *countingStrategy) Intervals() []time.Duration {
+  return []time.Duration{candle.OneMin}
+}
+
+func (o *countingStrategy) WarmUp() int {
+  return 0
+}
+
+func (o *countingStrategy) OnCandle(newCandle *candle.Candle) broker.Signal {
+  o.count++
+
+  return broker.None
+}
+
+func TestNewCreatesIndependentInstances(t *testing.T) {
+  Register("counting", func() (Strategy, error) {
+    return &countingStrategy{}, nil
+  })
+  defer unregister("counting")
+
+  first, err := New("counting")
+  if err != nil {
+    t.Fatalf("Failed to instantiate the first strategy. (Error: %s)", err)
+  }
+
+  second, err := New("counting")
+  if err != nil {
+    t.Fatalf("Failed to instantiate the second strategy. (Error: %s)", err)
+  }
+
+  first.OnCandle(nil)
+
+  if first.(*countingStrategy).count != 1 || second.(*countingStrategy).count != 0 {
+    t.Errorf("Expected strategy instances to be independent of each other, but they were not.")
+  }
+
+  if _, err := New("nonexistent"); err == nil {
+    t.Errorf("Expected instantiating an unregistered strategy to fail, but it did not.")
+  }
+}
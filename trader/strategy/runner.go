@@ -0,0 +1,121 @@
+package strategy
+
+import (
+  "fmt"
+  "github.com/lukehollenback/goose/constants"
+  "github.com/lukehollenback/goose/trader/broker"
+  "github.com/lukehollenback/goose/trader/candle"
+  "github.com/lukehollenback/goose/trader/monitor"
+  "github.com/lukehollenback/goose/trader/risk"
+  "log"
+)
+
+const (
+  Name = "≪strategy-runner≫"
+)
+
+var (
+  logger *log.Logger
+)
+
+func init() {
+  //
+  // Initialize the logger.
+  //
+  logger = log.New(log.Writer(), fmt.Sprintf(constants.LogPrefixFmt, Name), log.Ldate|log.Ltime|log.Lmsgprefix)
+}
+
+//
+// Runner feeds a strategy instance the closed candles of a single market and routes the signals
+// that it emits to the Broker Service (by way of the risk manager).
+//
+type Runner struct {
+  strategy      Strategy
+  asset         string
+  subscriptions []*monitor.Subscription
+  candleCnt     int // The number of candles that have been provided to the strategy instance.
+}
+
+//
+// Run subscribes the provided strategy instance to the candles that it requires from the Trade
+// Monitor Service for the specified asset's market. This must be done before the Monitor Service is
+// started.
+//
+func Run(s Strategy, asset string) (*Runner, error) {
+  o := &Runner{
+    strategy: s,
+    asset:    asset,
+  }
+
+  for _, interval := range s.Intervals() {
+    sub, err := monitor.Instance().Subscribe(asset, interval, o.candleCloseHandler)
+    if err != nil {
+      o.Stop()
+
+      return nil, err
+    }
+
+    o.subscriptions = append(o.subscriptions, sub)
+  }
+
+  return o, nil
+}
+
+//
+// Strategy returns the strategy instance that is being run.
+//
+func (o *Runner) Strategy() Strategy {
+  return o.strategy
+}
+
+//
+// Stop unsubscribes the strategy instance from the Trade Monitor Service so that it is no longer
+// provided with candles.
+//
+func (o *Runner) Stop() {
+  for _, sub := range o.subscriptions {
+    sub.Unsubscribe()
+  }
+
+  o.subscriptions = nil
+}
+
+//
+// candleCloseHandler provides a newly-closed candle to the strategy instance and routes any signal
+// that it emits to the Broker Service by way of the risk manager. Any sizing hint that the strategy
+// has is passed along first so that it applies to the signal.
+//
+func (o *Runner) candleCloseHandler(newCandle *candle.Candle) {
+  signal := o.deliver(newCandle)
+
+  if hinter, ok := o.strategy.(Hinter); ok {
+    broker.Instance().Hint(o.asset, hinter.SizeHint())
+  }
+
+  if signal != broker.None {
+    risk.Instance().Signal(o.asset, o.strategy.Name(), signal, newCandle.CloseAmt(), newCandle.End())
+  }
+}
+
+//
+// deliver provides a newly-closed candle to the strategy instance and returns the signal that it
+// emitted. Signals are suppressed until the strategy has been provided with as many candles as it
+// says that it needs to warm up, as they cannot be trusted before then.
+//
+func (o *Runner) deliver(newCandle *candle.Candle) broker.Signal {
+  o.candleCnt++
+
+  signal := o.strategy.OnCandle(newCandle)
+
+  if signal != broker.None && o.candleCnt < o.strategy.WarmUp() {
+    logger.Printf(
+      "Suppressed signal %s from %s for %s. It has only been provided with %d of the %d candles that it "+
+          "needs to warm up.",
+      signal, o.strategy.Name(), o.asset, o.candleCnt, o.strategy.WarmUp(),
+    )
+
+    return broker.None
+  }
+
+  return signal
+}
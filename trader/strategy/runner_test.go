@@ -0,0 +1,42 @@
+package strategy
+
+import (
+  "github.com/lukehollenback/goose/trader/broker"
+  "github.com/lukehollenback/goose/trader/candle"
+  "testing"
+  "time"
+)
+
+//
+// eagerStrategy is a trivial strategy that detects an uptrend on every candle, even though it claims
+// to need a few candles to warm up.
+//
+type eagerStrategy struct{}
+
+func (o *eagerStrategy) Name() string {
+  return "eager"
+}
+
+func (o *eagerStrategy) Intervals() []time.Duration {
+  return []time.Duration{candle.OneMin}
+}
+
+func (o *eagerStrategy) WarmUp() int {
+  return 3
+}
+
+func (o *eagerStrategy) OnCandle(newCandle *candle.Candle) broker.Signal {
+  return broker.UptrendDetected
+}
+
+func TestRunnerSuppressesSignalsUntilWarmedUp(t *testing.T) {
+  o := &Runner{strategy: &eagerStrategy{}, asset: "BTC"}
+
+  for i := 1; i <= 4; i++ {
+    signal := o.deliver(nil)
+
+    if warm := i >= 3; warm != (signal == broker.UptrendDetected) {
+      t.Errorf("Expected the signal from candle %d to be suppressed only while warming up, but got %s.", i, signal)
+    }
+  }
+}
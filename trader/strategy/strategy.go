@@ -0,0 +1,53 @@
+package strategy
+
+import (
+  "github.com/lukehollenback/goose/trader/broker"
+  "github.com/lukehollenback/goose/trader/candle"
+  "time"
+)
+
+//
+// Strategy generically provides an interface to any trading algorithm. A strategy instance only
+// ever sees the candles of a single market, and it does not act upon its own signals – it simply
+// returns them so that whoever is running it can route them to the Broker Service.
+//
+type Strategy interface {
+
+  //
+  // Name returns the name that the strategy is registered under.
+  //
+  Name() string
+
+  //
+  // Intervals returns the intervals of candles that the strategy needs to be provided with.
+  //
+  Intervals() []time.Duration
+
+  //
+  // WarmUp returns the number of candles that the strategy needs to be provided with before it is
+  // able to emit any signals. Whoever is running the strategy suppresses any signal that it emits
+  // before then.
+  //
+  WarmUp() int
+
+  //
+  // OnCandle provides the strategy with a newly-closed candle of one of the intervals that it
+  // requires. Returns the signal (if any) that the candle triggered.
+  //
+  OnCandle(newCandle *candle.Candle) broker.Signal
+
+}
+
+//
+// Hinter may optionally be implemented by a strategy that has advice about how new positions in its
+// market should be sized. Whoever is running the strategy passes the hint along to the Broker
+// Service every time that the strategy is provided with a candle.
+//
+type Hinter interface {
+
+  //
+  // SizeHint returns the strategy's current advice about how new positions should be sized.
+  //
+  SizeHint() broker.SizeHint
+
+}
@@ -2,6 +2,7 @@ package writer
 
 import (
   "encoding/csv"
+  "errors"
   "flag"
   "fmt"
   "github.com/lukehollenback/goose/constants"
@@ -15,6 +16,7 @@ import (
 const (
   Name         = "≪writer-service≫"
   TimestampKey = "Timestamp"
+  AssetKey     = "Asset"
   MaxFill      = 1000
 )
 
@@ -115,7 +117,9 @@ func (o *Service) Start() (<-chan bool, error) {
   //
   o.writer = csv.NewWriter(o.outputFile)
 
-  err = o.writer.Write([]string{TimestampKey, ClosingPrice.String(), GrossMockEarnings.String()})
+  err = o.writer.Write([]string{
+    TimestampKey, AssetKey, ClosingPrice.String(), GrossMockEarnings.String(), RiskHalt.String(),
+  })
   if err != nil {
     o.chStopped <- true
 
@@ -176,30 +180,51 @@ func (o *Service) Stop() (<-chan bool, error) {
 }
 
 //
-// Write outputs the provided data point to the current CSV output file.
+// Write outputs the provided data point, which does not pertain to any specific asset (e.g. because
+// it describes the whole portfolio), to the current CSV output file.
 //
 // NOTE ~> This method logs its own failures, but also returns them in case the caller wants to
 //  pivot on them as well.
 //
 func (o *Service) Write(timestamp time.Time, category Type, value decimal.Decimal) error {
+  return o.WriteFor(timestamp, "", category, value)
+}
+
+//
+// WriteFor outputs the provided data point, which pertains to the specified asset, to the current
+// CSV output file.
+//
+// NOTE ~> This method logs its own failures, but also returns them in case the caller wants to
+//  pivot on them as well.
+//
+func (o *Service) WriteFor(timestamp time.Time, asset string, category Type, value decimal.Decimal) error {
   o.mu.Lock()
   defer o.mu.Unlock()
 
   var err error
 
+  //
+  // Bail out if the service has not been started, as there is nowhere to write the line to.
+  //
+  if o.writer == nil {
+    return errors.New("cannot write out data points before the writer service has been started")
+  }
+
   //
   // Write out the line to the CSV file.
   //
   if category == ClosingPrice {
-    err = o.writer.Write([]string{timestamp.String(), value.String(), ""})
+    err = o.writer.Write([]string{timestamp.String(), asset, value.String(), "", ""})
   } else if category == GrossMockEarnings {
-    err = o.writer.Write([]string{timestamp.String(), "", value.String()})
+    err = o.writer.Write([]string{timestamp.String(), asset, "", value.String(), ""})
+  } else if category == RiskHalt {
+    err = o.writer.Write([]string{timestamp.String(), asset, "", "", value.String()})
   }
 
   if err != nil {
     logger.Printf(
-      "Failed to write out data point. (Timestamp: %s, Category: %s, Value: %s) (Error: %s)",
-      timestamp, category, value, err,
+      "Failed to write out data point. (Timestamp: %s, Asset: %s, Category: %s, Value: %s) (Error: %s)",
+      timestamp, asset, category, value, err,
     )
   }
 
@@ -8,8 +8,9 @@ type Type int
 const (
   ClosingPrice Type = iota
   GrossMockEarnings
+  RiskHalt
 )
 
 func (o Type) String() string {
-  return [...]string{"ClosingPrice", "GrossMockEarnings"}[o]
+  return [...]string{"ClosingPrice", "GrossMockEarnings", "RiskHalt"}[o]
 }
\ No newline at end of file